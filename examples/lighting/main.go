@@ -0,0 +1,94 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/light"
+	"github.com/weakpixel/ebitenkiso/pkg/ticker"
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	screenWidth  = 640
+	screenHeight = 360
+)
+
+func main() {
+	ebiten.SetWindowSize(screenWidth*2, screenHeight*2)
+	ebiten.SetWindowTitle("Lighting Example")
+
+	lights, err := light.New()
+	if err != nil {
+		panic(err)
+	}
+	box := xmath.Polygon{Points: []xmath.Vector2{{X: -20, Y: -20}, {X: 20, Y: -20}, {X: 20, Y: 20}, {X: -20, Y: 20}}}
+	for _, pos := range []xmath.Vector2{{X: 200, Y: 120}, {X: 420, Y: 220}, {X: 320, Y: 80}} {
+		lights.AddOccluder(&light.Occluder{Polygon: box, Position: pos})
+	}
+	g := &Game{
+		ticker: ticker.Time(),
+		lights: lights,
+		cursor: lights.Add(light.NewPoint(xmath.Vector2{}, 220, color.RGBA{255, 220, 160, 255})),
+		spot:   lights.Add(light.NewSpot(xmath.Vector2{X: 80, Y: 300}, 400, 0, math.Pi/8, color.RGBA{120, 160, 255, 255})),
+		scene:  ebiten.NewImage(screenWidth, screenHeight),
+	}
+	if err := ebiten.RunGame(g); err != nil {
+		panic(err)
+	}
+}
+
+type Game struct {
+	ticker  ticker.Ticker
+	lights  *light.System
+	cursor  *light.Light
+	spot    *light.Light
+	scene   *ebiten.Image
+	elapsed time.Duration
+}
+
+func (g *Game) Update() error {
+	g.elapsed += g.ticker.Tick()
+	x, y := ebiten.CursorPosition()
+	g.cursor.Position = xmath.Vector2{X: float64(x), Y: float64(y)}
+	g.spot.Direction = -math.Pi/4 + math.Sin(g.elapsed.Seconds())*math.Pi/6
+	for idx, o := range g.lights.Occluders {
+		o.Rotation = g.elapsed.Seconds() * float64(idx+1) / 4
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.lights.Begin(screenWidth, screenHeight)
+	g.scene.Fill(color.RGBA{180, 180, 170, 255})
+	for _, o := range g.lights.Occluders {
+		var path vector.Path
+		for idx, p := range o.Polygon.Transform(o.Position, o.Rotation) {
+			if idx == 0 {
+				path.MoveTo(float32(p.X), float32(p.Y))
+			} else {
+				path.LineTo(float32(p.X), float32(p.Y))
+			}
+		}
+		path.Close()
+		vector.FillPath(g.scene, &path, &vector.FillOptions{}, &vector.DrawPathOptions{
+			AntiAlias:  true,
+			ColorScale: colorScale(color.RGBA{90, 60, 50, 255}),
+		})
+	}
+	g.lights.Draw(g.scene, screen, &ebiten.DrawRectShaderOptions{})
+}
+
+func colorScale(c color.RGBA) ebiten.ColorScale {
+	var cs ebiten.ColorScale
+	cs.ScaleWithColor(c)
+	return cs
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
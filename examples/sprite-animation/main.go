@@ -11,6 +11,7 @@ import (
 	"github.com/weakpixel/ebitenkiso/pkg/sprites/aseprite"
 	"github.com/weakpixel/ebitenkiso/pkg/ticker"
 	"github.com/weakpixel/ebitenkiso/pkg/tween"
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
 
 	"image/color"
 	_ "image/jpeg"
@@ -26,29 +27,38 @@ func start() {
 	ebiten.SetWindowSize(800, 600)
 	ebiten.SetWindowTitle("Sprite Animation Example")
 
+	noise, err := shader.NewNoise(time.Millisecond * 50)
+	if err != nil {
+		panic(err)
+	}
+	abbr, err := shader.NewAbberation(10)
+	if err != nil {
+		panic(err)
+	}
+	post = shader.NewPipeline(noise, abbr)
+
 	g := Game{
 		// sprite: sprite,
 		ticker: ticker.Time(),
 	}
-	err := ebiten.RunGame(&g)
+	err = ebiten.RunGame(&g)
 	if err != nil {
 		panic(err)
 	}
 }
 
 var (
-	pixels     = float64(350)
-	moveTween  = tween.NewSeq(tween.New(0, pixels, time.Second, tween.InExpo), tween.New(pixels, 0, time.Second, tween.InExpo))
-	moveTweenY = tween.NewSeq(tween.New(0, pixels, time.Second, tween.InExpo), tween.New(pixels, 0, time.Second, tween.InExpo))
+	pixels    = float64(350)
+	moveSeq   = tween.NewSeq(tween.Progress(time.Second, tween.InExpo), tween.New(1, 0, time.Second, tween.InExpo))
+	moveTween = tween.NewVector(xmath.Vector2{}, xmath.Vector2{X: pixels, Y: pixels}, moveSeq)
 
-	noise = shader.NewNoise(time.Millisecond * 50)
-	other = shader.NewAbberation(10)
+	post *shader.Pipeline
 )
 
 type Game struct {
 	sprite *sprites.Sprite
 	ticker ticker.Ticker
-	x, y   float64
+	pos    xmath.Vector2
 }
 
 func (g *Game) Update() error {
@@ -59,32 +69,30 @@ func (g *Game) Update() error {
 		if err != nil {
 			panic(err)
 		}
-		moveTween.SetLoop(true)
-		moveTweenY.SetLoop(true)
-		sprite.Shader = shader.NewAbberation(3)
+		moveSeq.SetLoop(true)
+		sprite.Shader, err = shader.NewAbberation(3)
+		if err != nil {
+			panic(err)
+		}
 
 		g.sprite = sprite
 	}
 
 	dt := g.ticker.Tick()
-	noise.Update(dt)
-	other.Update(dt)
-	g.x, _ = moveTween.Update(dt)
-	g.y, _ = moveTweenY.Update(dt)
+	post.Update(dt)
+	g.pos, _ = moveTween.Update(dt)
 	g.sprite.Update(dt)
 	return nil
 }
 
 var buff = ebiten.NewImage(800, 600)
-var buff2 = ebiten.NewImage(800, 600)
 
 func init() {
 	buff.Fill(color.RGBA{0, 0, 0, 255})
 }
 func (g *Game) Draw(screen *ebiten.Image) {
-	noise.Draw(buff, buff2, &ebiten.DrawRectShaderOptions{})
-	other.Draw(buff2, screen, &ebiten.DrawRectShaderOptions{})
-	g.sprite.Draw(g.x, g.y, false, screen, ebiten.ColorScale{})
+	post.Draw(buff, screen, &ebiten.DrawRectShaderOptions{})
+	g.sprite.Draw(g.pos.X, g.pos.Y, false, screen, ebiten.ColorScale{})
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
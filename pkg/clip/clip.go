@@ -0,0 +1,115 @@
+package clip
+
+import (
+	"sort"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+// Clip animates named float properties with keyframes. Position, rotation,
+// scale, color channels and shader uniforms are all plain properties, e.g.
+// "x", "rotation", "color.a" or "uniform.Intensity".
+type Clip struct {
+	Name     string
+	Duration time.Duration
+	Loop     bool
+	Tracks   []*Track
+}
+
+// Track holds the keys of one property sorted by time.
+type Track struct {
+	Property string
+	Keys     []Key
+}
+
+// Key is a value at a time. Ease shapes the way from this key to the next,
+// nil is linear.
+type Key struct {
+	Time  time.Duration
+	Value float64
+	Ease  tween.TweenFunc
+}
+
+// New creates a clip, the duration defaults to the last key.
+func New(name string, tracks ...*Track) *Clip {
+	c := &Clip{
+		Name:   name,
+		Tracks: tracks,
+	}
+	for _, t := range tracks {
+		t.sort()
+		if len(t.Keys) > 0 {
+			c.Duration = max(c.Duration, t.Keys[len(t.Keys)-1].Time)
+		}
+	}
+	return c
+}
+
+// NewTrack creates a track with keys in any order.
+func NewTrack(property string, keys ...Key) *Track {
+	t := &Track{
+		Property: property,
+		Keys:     keys,
+	}
+	t.sort()
+	return t
+}
+
+func (t *Track) sort() {
+	sort.SliceStable(t.Keys, func(i, j int) bool {
+		return t.Keys[i].Time < t.Keys[j].Time
+	})
+}
+
+// Sample returns the value at time at, before the first and after the last
+// key the value of that key.
+func (t *Track) Sample(at time.Duration) float64 {
+	if len(t.Keys) == 0 {
+		return 0
+	}
+	idx := sort.Search(len(t.Keys), func(i int) bool {
+		return t.Keys[i].Time > at
+	})
+	if idx == 0 {
+		return t.Keys[0].Value
+	}
+	if idx == len(t.Keys) {
+		return t.Keys[idx-1].Value
+	}
+	a, b := t.Keys[idx-1], t.Keys[idx]
+	ease := a.Ease
+	if ease == nil {
+		ease = tween.Linear
+	}
+	return ease((at - a.Time).Seconds(), a.Value, b.Value-a.Value, (b.Time - a.Time).Seconds())
+}
+
+// Time maps a play time to the clip time, wrapping looping clips.
+func (c *Clip) Time(at time.Duration) time.Duration {
+	if c.Loop && c.Duration > 0 {
+		at %= c.Duration
+		if at < 0 {
+			at += c.Duration
+		}
+		return at
+	}
+	return min(max(at, 0), c.Duration)
+}
+
+// Sample calls fn with the value of every track at play time at.
+func (c *Clip) Sample(at time.Duration, fn func(property string, v float64)) {
+	at = c.Time(at)
+	for _, t := range c.Tracks {
+		fn(t.Property, t.Sample(at))
+	}
+}
+
+// Apply writes the values at play time at to target.
+func (c *Clip) Apply(at time.Duration, target Target) {
+	c.Sample(at, func(property string, v float64) {
+		if p := target.Property(property); p != nil {
+			*p = v
+		}
+	})
+}
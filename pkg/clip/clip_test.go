@@ -0,0 +1,118 @@
+package clip
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+const ms = time.Millisecond
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTrackSample(t *testing.T) {
+	tr := NewTrack("x",
+		Key{Time: 200 * ms, Value: 0},
+		Key{Time: 0, Value: 0, Ease: tween.InQuad},
+		Key{Time: 100 * ms, Value: 100},
+	)
+	for at, expected := range map[time.Duration]float64{
+		-ms:       0,
+		50 * ms:   25,
+		100 * ms:  100,
+		150 * ms:  50,
+		time.Hour: 0,
+	} {
+		if v := tr.Sample(at); !near(v, expected) {
+			t.Errorf("at %s: expected %f but got %f", at, expected, v)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c, err := Load(strings.NewReader(`{"name": "bob", "loop": true, "tracks": [
+		{"property": "y", "keys": [{"time": 0, "value": 0, "ease": "out-quad"}, {"time": 0.5, "value": 10}]},
+		{"property": "uniform.Intensity", "keys": [{"time": 1, "value": 1}, {"time": 0, "value": 0}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Duration != time.Second || !c.Loop || len(c.Tracks) != 2 {
+		t.Fatalf("unexpected clip %+v", c)
+	}
+	var y, intensity float64
+	c.Apply(1250*ms, Properties{"y": &y, "uniform.Intensity": &intensity})
+	if !near(y, 7.5) || !near(intensity, 0.25) {
+		t.Errorf("expected the loop to wrap to y=7.5 intensity=0.25 but got %f %f", y, intensity)
+	}
+	if _, err := Load(strings.NewReader(`{"tracks": [{"property": "x", "keys": [{"ease": "wobble"}]}]}`)); err == nil {
+		t.Error("expected an error for an unknown easing")
+	}
+}
+
+func TestPlayerBlend(t *testing.T) {
+	x := 10.0
+	walk := New("walk", NewTrack("x", Key{Time: 0, Value: 100}, Key{Time: time.Second, Value: 100}))
+	run := New("run", NewTrack("x", Key{Time: 0, Value: 200}, Key{Time: time.Second, Value: 200}))
+
+	p := NewPlayer(Properties{"x": &x})
+	l := p.Blend(walk, 0.5)
+	p.Update(10 * ms)
+	if !near(x, 55) {
+		t.Errorf("expected half of the clip over the rest value but got %f", x)
+	}
+	l.Weight = 1
+	p.Blend(run, 1)
+	p.Update(10 * ms)
+	if !near(x, 150) {
+		t.Errorf("expected the average of both clips but got %f", x)
+	}
+
+	completed := false
+	p.Play(walk).OnComplete = func() { completed = true }
+	p.CrossFade(run, 100*ms)
+	p.Update(50 * ms)
+	if !near(x, 150) || len(p.Layers()) != 2 {
+		t.Errorf("expected the cross fade halfway at 150 but got %f", x)
+	}
+	p.Update(50 * ms)
+	p.Update(time.Second)
+	if x != 200 || len(p.Layers()) != 1 || completed {
+		t.Errorf("expected only the run clip left but got x=%f layers=%d", x, len(p.Layers()))
+	}
+}
+
+func TestPlayerReleasesStoppedProperties(t *testing.T) {
+	x, y := 10.0, 0.0
+	move := New("move", NewTrack("x", Key{Time: 0, Value: 100}, Key{Time: time.Second, Value: 100}))
+	fall := New("fall", NewTrack("y", Key{Time: 0, Value: 5}, Key{Time: time.Second, Value: 5}))
+
+	p := NewPlayer(Properties{"x": &x, "y": &y})
+	l := p.Blend(move, 0.5)
+	p.Blend(fall, 1)
+	p.Update(10 * ms)
+	p.Stop(l)
+	x = 42
+	p.Update(10 * ms)
+	if x != 42 {
+		t.Errorf("expected a stopped clip to leave x alone but got %f", x)
+	}
+	l = p.Blend(move, 0.5)
+	p.Update(10 * ms)
+	if !near(x, 71) {
+		t.Errorf("expected the new rest value to be 42 but got %f", x)
+	}
+
+	l.FadeTo(0, 0)
+	x = 42
+	p.Update(10 * ms)
+	p.Update(10 * ms)
+	if x != 42 || y != 5 {
+		t.Errorf("expected the faded out clip to leave the others playing but got x=%f y=%f", x, y)
+	}
+}
@@ -0,0 +1,71 @@
+package clip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+// clipData is the JSON form of a clip, times are in seconds:
+//
+//	{"name": "intro", "loop": false, "tracks": [
+//		{"property": "x", "keys": [{"time": 0, "value": 0, "ease": "outQuad"}, {"time": 0.5, "value": 100}]}
+//	]}
+type clipData struct {
+	Name     string      `json:"name"`
+	Duration float64     `json:"duration"`
+	Loop     bool        `json:"loop"`
+	Tracks   []trackData `json:"tracks"`
+}
+
+type trackData struct {
+	Property string    `json:"property"`
+	Keys     []keyData `json:"keys"`
+}
+
+type keyData struct {
+	Time  float64 `json:"time"`
+	Value float64 `json:"value"`
+	Ease  string  `json:"ease"`
+}
+
+// Load reads a clip from JSON. Easings are looked up by name with
+// tween.Easing, a duration of 0 defaults to the last key.
+func Load(r io.Reader) (*Clip, error) {
+	var d clipData
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("json.Decode failed: %w", err)
+	}
+	tracks := make([]*Track, len(d.Tracks))
+	for idx, td := range d.Tracks {
+		keys := make([]Key, len(td.Keys))
+		for kidx, kd := range td.Keys {
+			keys[kidx] = Key{
+				Time:  seconds(kd.Time),
+				Value: kd.Value,
+			}
+			if kd.Ease == "" {
+				continue
+			}
+			ease, ok := tween.Easing(kd.Ease)
+			if !ok {
+				return nil, fmt.Errorf("clip %q track %q: unknown easing %q", d.Name, td.Property, kd.Ease)
+			}
+			keys[kidx].Ease = ease
+		}
+		tracks[idx] = NewTrack(td.Property, keys...)
+	}
+	c := New(d.Name, tracks...)
+	c.Loop = d.Loop
+	if d.Duration > 0 {
+		c.Duration = seconds(d.Duration)
+	}
+	return c, nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,206 @@
+package clip
+
+import (
+	"time"
+)
+
+func NewPlayer(target Target) *Player {
+	return &Player{
+		Target: target,
+		Speed:  1,
+		rest:   map[string]float64{},
+	}
+}
+
+// Player plays clips on a target and blends them by weight. Where the
+// weights sum to less than 1 the value the property had before the player
+// touched it fills the rest. Properties no clip drives anymore are left
+// as they are.
+type Player struct {
+	Target Target
+	Speed  float64
+	layers []*Layer
+	rest   map[string]float64
+	sums   map[string]*blend
+}
+
+// Layer is a clip playing on a Player.
+type Layer struct {
+	Clip       *Clip
+	Weight     float64
+	OnComplete func()
+	elapsed    time.Duration
+	fadeFrom   float64
+	fadeTo     float64
+	fade       time.Duration
+	faded      time.Duration
+	completed  bool
+	out        bool
+}
+
+type blend struct {
+	sum    float64
+	weight float64
+}
+
+// Play stops all clips and plays c at full weight.
+func (p *Player) Play(c *Clip) *Layer {
+	p.layers = nil
+	p.release()
+	return p.Blend(c, 1)
+}
+
+// Blend plays c on top of the running clips with weight.
+func (p *Player) Blend(c *Clip, weight float64) *Layer {
+	l := &Layer{
+		Clip:   c,
+		Weight: weight,
+	}
+	p.layers = append(p.layers, l)
+	return l
+}
+
+// CrossFade fades c in and all running clips out over d.
+func (p *Player) CrossFade(c *Clip, d time.Duration) *Layer {
+	for _, l := range p.layers {
+		l.FadeTo(0, d)
+	}
+	l := p.Blend(c, 0)
+	l.FadeTo(1, d)
+	return l
+}
+
+// Stop removes l.
+func (p *Player) Stop(l *Layer) {
+	for idx, other := range p.layers {
+		if other == l {
+			p.layers = append(p.layers[:idx], p.layers[idx+1:]...)
+			p.release()
+			return
+		}
+	}
+}
+
+// release forgets the blend state of properties no layer has a track for.
+func (p *Player) release() {
+	driven := map[string]bool{}
+	for _, l := range p.layers {
+		for _, tr := range l.Clip.Tracks {
+			driven[tr.Property] = true
+		}
+	}
+	for property := range p.sums {
+		if !driven[property] {
+			delete(p.sums, property)
+		}
+	}
+	for property := range p.rest {
+		if !driven[property] {
+			delete(p.rest, property)
+		}
+	}
+}
+
+func (p *Player) Layers() []*Layer {
+	return p.layers
+}
+
+// FadeTo changes the weight to weight over d, a layer faded to 0 is
+// removed.
+func (l *Layer) FadeTo(weight float64, d time.Duration) {
+	l.fadeFrom, l.fadeTo = l.Weight, weight
+	l.fade, l.faded = d, 0
+	if d <= 0 {
+		l.Weight = weight
+		l.out = weight <= 0
+	}
+}
+
+func (l *Layer) Elapsed() time.Duration {
+	return l.elapsed
+}
+
+// Seek moves the layer to play time at.
+func (l *Layer) Seek(at time.Duration) {
+	l.elapsed = at
+	l.completed = false
+}
+
+// Done reports whether a clip that does not loop reached its end.
+func (l *Layer) Done() bool {
+	return !l.Clip.Loop && l.elapsed >= l.Clip.Duration
+}
+
+func (l *Layer) update(dt time.Duration) {
+	l.elapsed += dt
+	if l.fade > 0 {
+		l.faded = min(l.faded+dt, l.fade)
+		t := float64(l.faded) / float64(l.fade)
+		l.Weight = l.fadeFrom + (l.fadeTo-l.fadeFrom)*t
+		if l.faded == l.fade {
+			l.fade = 0
+			l.out = l.Weight <= 0
+		}
+	}
+}
+
+func (p *Player) Update(dt time.Duration) {
+	dt = time.Duration(float64(dt) * p.Speed)
+	if p.sums == nil {
+		p.sums = map[string]*blend{}
+	}
+	for _, b := range p.sums {
+		*b = blend{}
+	}
+	active := p.layers[:0]
+	for _, l := range p.layers {
+		l.update(dt)
+		if l.out {
+			continue
+		}
+		active = append(active, l)
+		l.Clip.Sample(l.elapsed, func(property string, v float64) {
+			b := p.sums[property]
+			if b == nil {
+				b = &blend{}
+				p.sums[property] = b
+			}
+			b.sum += v * l.Weight
+			b.weight += l.Weight
+		})
+	}
+	clear(p.layers[len(active):])
+	p.layers = active
+
+	for property, b := range p.sums {
+		if b.weight == 0 {
+			delete(p.sums, property)
+			delete(p.rest, property)
+			continue
+		}
+		target := p.Target.Property(property)
+		if target == nil {
+			continue
+		}
+		rest, ok := p.rest[property]
+		if !ok {
+			rest = *target
+			p.rest[property] = rest
+		}
+		switch {
+		case b.weight >= 1:
+			*target = b.sum / b.weight
+		default:
+			*target = b.sum + rest*(1-b.weight)
+		}
+	}
+
+	for _, l := range p.layers {
+		if !l.completed && l.Done() {
+			l.completed = true
+			if l.OnComplete != nil {
+				l.OnComplete()
+			}
+		}
+	}
+}
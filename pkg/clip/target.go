@@ -0,0 +1,28 @@
+package clip
+
+// Target exposes named float properties to clips, Property returns nil
+// for unknown names.
+type Target interface {
+	Property(name string) *float64
+}
+
+// Properties is a Target binding names to fields:
+//
+//	clip.Properties{"x": &s.X, "y": &s.Y, "uniform.Intensity": mat.Float("Intensity")}
+type Properties map[string]*float64
+
+func (p Properties) Property(name string) *float64 {
+	return p[name]
+}
+
+// Targets combines targets, the first one knowing a property wins.
+type Targets []Target
+
+func (t Targets) Property(name string) *float64 {
+	for _, target := range t {
+		if p := target.Property(name); p != nil {
+			return p
+		}
+	}
+	return nil
+}
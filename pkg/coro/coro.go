@@ -0,0 +1,202 @@
+package coro
+
+import (
+	"iter"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/timer"
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+// Func is the body of a task. It runs inside Update and suspends in the
+// waits of Ctx, so timed gameplay can be written as a linear script:
+//
+//	coro.Start(func(c *coro.Ctx) {
+//		c.Tween(tween.New(0, 100, time.Second, tween.OutQuad), func(v float64) { boss.X = v })
+//		c.Wait(500 * time.Millisecond)
+//		c.Parallel(shootLeft, shootRight)
+//	})
+type Func func(c *Ctx)
+
+type cancelled struct{}
+
+// Start creates a task, the body runs on the first Update.
+func Start(fn Func) *Task {
+	t := &Task{}
+	t.ctx = &Ctx{task: t}
+	t.next, t.stop = iter.Pull(func(yield func(struct{}) bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(cancelled); !ok {
+					panic(r)
+				}
+			}
+		}()
+		t.ctx.yield = yield
+		fn(t.ctx)
+	})
+	return t
+}
+
+// Task is a running script, it is advanced by Update.
+type Task struct {
+	ctx       *Ctx
+	next      func() (struct{}, bool)
+	stop      func()
+	running   bool
+	done      bool
+	cancelled bool
+}
+
+// Update resumes the task with the time of the frame and returns true once
+// the body returned or the task was cancelled.
+func (t *Task) Update(dt time.Duration) bool {
+	if t.done {
+		return true
+	}
+	t.ctx.avail = dt
+	t.ctx.dt = dt
+	t.running = true
+	_, ok := t.next()
+	t.running = false
+	if !ok {
+		t.done = true
+	}
+	return t.done
+}
+
+// Cancel stops the task, deferred calls of the body run before it returns.
+// A task cancelling itself stops at its next wait.
+func (t *Task) Cancel() {
+	if t.done {
+		return
+	}
+	t.cancelled = true
+	if !t.running {
+		t.stop()
+		t.done = true
+	}
+}
+
+func (t *Task) Done() bool {
+	return t.done
+}
+
+func (t *Task) Cancelled() bool {
+	return t.cancelled
+}
+
+// Ctx is passed to the body of a task. Waits consume the time of the
+// current frame, time left over when a wait ends is available to the
+// next wait in the same frame.
+type Ctx struct {
+	task  *Task
+	yield func(struct{}) bool
+	dt    time.Duration
+	avail time.Duration
+}
+
+// Dt returns the duration of the current frame.
+func (c *Ctx) Dt() time.Duration {
+	return c.dt
+}
+
+// Yield suspends the task until the next frame.
+func (c *Ctx) Yield() {
+	c.avail = 0
+	if c.task.cancelled || !c.yield(struct{}{}) || c.task.cancelled {
+		panic(cancelled{})
+	}
+}
+
+// Wait suspends the task for d.
+func (c *Ctx) Wait(d time.Duration) {
+	left := d
+	for {
+		step := min(c.avail, left)
+		c.avail -= step
+		left -= step
+		if left <= 0 {
+			return
+		}
+		c.Yield()
+	}
+}
+
+// WaitUntil suspends the task until cond returns true, cond is checked once
+// per frame.
+func (c *Ctx) WaitUntil(cond func() bool) {
+	for !cond() {
+		c.Yield()
+	}
+}
+
+// WaitFor steps u every frame until it is done.
+func (c *Ctx) WaitFor(u tween.Updater) {
+	c.Tween(u, nil)
+}
+
+// Tween steps u every frame and passes its value to set until it is done.
+// The tween consumes the whole frame.
+func (c *Ctx) Tween(u tween.Updater, set func(v float64)) {
+	for {
+		v, done := u.Update(c.avail)
+		c.avail = 0
+		if set != nil {
+			set(v)
+		}
+		if done {
+			return
+		}
+		c.Yield()
+	}
+}
+
+// WaitTask suspends the task until the scheduled task fired for the last
+// time or was cancelled.
+func (c *Ctx) WaitTask(t *timer.Task) {
+	c.WaitUntil(t.Done)
+}
+
+// Parallel runs fns as sub tasks and waits until all of them are done.
+func (c *Ctx) Parallel(fns ...Func) {
+	c.branches(fns, false)
+}
+
+// Race runs fns as sub tasks until the first is done, the others are
+// cancelled.
+func (c *Ctx) Race(fns ...Func) {
+	c.branches(fns, true)
+}
+
+func (c *Ctx) branches(fns []Func, race bool) {
+	tasks := make([]*Task, len(fns))
+	for idx, fn := range fns {
+		tasks[idx] = Start(fn)
+	}
+	defer func() {
+		for _, t := range tasks {
+			t.Cancel()
+		}
+	}()
+	for {
+		all, any := true, false
+		left := c.avail
+		for _, t := range tasks {
+			if t.done {
+				continue
+			}
+			if t.Update(c.avail) {
+				any = true
+				left = min(left, t.ctx.avail)
+			} else {
+				all = false
+			}
+		}
+		if all || race && any {
+			c.avail = left
+			return
+		}
+		c.Yield()
+	}
+}
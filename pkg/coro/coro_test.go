@@ -0,0 +1,137 @@
+package coro
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/timer"
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+const ms = time.Millisecond
+
+func TestWaitCarriesOver(t *testing.T) {
+	now := time.Duration(0)
+	calls := []time.Duration{}
+	task := Start(func(c *Ctx) {
+		c.Wait(100 * ms)
+		calls = append(calls, now)
+		c.Wait(15 * ms)
+		calls = append(calls, now)
+		c.Wait(50 * ms)
+		calls = append(calls, now)
+	})
+	for range 10 {
+		now += 30 * ms
+		if task.Update(30 * ms) {
+			break
+		}
+	}
+	expected := []time.Duration{120 * ms, 120 * ms, 180 * ms}
+	if !slices.Equal(calls, expected) || !task.Done() {
+		t.Errorf("expected waits to end at %v but got %v", expected, calls)
+	}
+}
+
+func TestWaitUntilAndTween(t *testing.T) {
+	ready := false
+	x := 0.0
+	task := Start(func(c *Ctx) {
+		c.WaitUntil(func() bool { return ready })
+		c.Tween(tween.New(0, 10, 40*ms, tween.Linear), func(v float64) { x = v })
+	})
+	task.Update(10 * ms)
+	task.Update(10 * ms)
+	if x != 0 {
+		t.Errorf("tween must not start before the condition but x=%f", x)
+	}
+	ready = true
+	task.Update(10 * ms)
+	if x != 2.5 {
+		t.Errorf("expected x=2.5 but got %f", x)
+	}
+	for range 4 {
+		task.Update(10 * ms)
+	}
+	if x != 10 || !task.Done() {
+		t.Errorf("expected the tween to finish at 10 but got %f", x)
+	}
+}
+
+func TestParallelAndRace(t *testing.T) {
+	order := []string{}
+	mark := func(name string, d time.Duration) Func {
+		return func(c *Ctx) {
+			c.Wait(d)
+			order = append(order, name)
+		}
+	}
+	cleaned := false
+	task := Start(func(c *Ctx) {
+		c.Parallel(mark("a", 30*ms), mark("b", 10*ms))
+		order = append(order, "parallel")
+		c.Race(mark("c", 20*ms), func(c *Ctx) {
+			defer func() { cleaned = true }()
+			c.Wait(time.Second)
+			order = append(order, "never")
+		})
+		order = append(order, "race")
+	})
+	for range 10 {
+		task.Update(10 * ms)
+	}
+	expected := []string{"b", "a", "parallel", "c", "race"}
+	if !slices.Equal(order, expected) {
+		t.Errorf("expected %v but got %v", expected, order)
+	}
+	if !cleaned {
+		t.Error("expected the losing branch to be cancelled")
+	}
+}
+
+func TestCancel(t *testing.T) {
+	steps, cleaned := 0, false
+	r := NewRunner()
+	task := r.Start(func(c *Ctx) {
+		defer func() { cleaned = true }()
+		for {
+			steps++
+			c.Yield()
+		}
+	})
+	r.Update(ms)
+	r.Update(ms)
+	task.Cancel()
+	r.Update(ms)
+	if steps != 2 || !cleaned || !task.Cancelled() || r.Len() != 0 {
+		t.Errorf("expected the task to stop after 2 steps but got %d cleaned=%t", steps, cleaned)
+	}
+
+	self := r.Start(func(c *Ctx) {
+		c.task.Cancel()
+		c.Yield()
+		steps++
+	})
+	r.Update(ms)
+	if !self.Done() || steps != 2 {
+		t.Error("expected a task to be able to cancel itself")
+	}
+}
+
+func TestWaitTask(t *testing.T) {
+	s := timer.NewScheduler()
+	fired := s.Repeat(10*ms, 2, func() {})
+	done := false
+	task := Start(func(c *Ctx) {
+		c.WaitTask(fired)
+		done = true
+	})
+	for range 2 {
+		s.Update(10 * ms)
+		task.Update(10 * ms)
+	}
+	if !done {
+		t.Error("expected the task to continue after the scheduled task")
+	}
+}
@@ -0,0 +1,46 @@
+package coro
+
+import "time"
+
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Runner updates a set of tasks and drops them once they are done.
+type Runner struct {
+	tasks []*Task
+}
+
+// Start adds a task, tasks started during Update run from the next Update.
+func (r *Runner) Start(fn Func) *Task {
+	t := Start(fn)
+	r.tasks = append(r.tasks, t)
+	return t
+}
+
+func (r *Runner) Len() int {
+	return len(r.tasks)
+}
+
+// Clear cancels all tasks.
+func (r *Runner) Clear() {
+	for _, t := range r.tasks {
+		t.Cancel()
+	}
+	r.tasks = nil
+}
+
+func (r *Runner) Update(dt time.Duration) {
+	n := len(r.tasks)
+	for idx := 0; idx < n && idx < len(r.tasks); idx++ {
+		r.tasks[idx].Update(dt)
+	}
+	active := r.tasks[:0]
+	for _, t := range r.tasks {
+		if !t.done {
+			active = append(active, t)
+		}
+	}
+	clear(r.tasks[len(active):])
+	r.tasks = active
+}
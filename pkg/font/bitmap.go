@@ -0,0 +1,137 @@
+package font
+
+import (
+	"image"
+	"math"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// LoadBitmap loads a BMFont descriptor and the page images next to it.
+func LoadBitmap(resource res.Resource) (*Bitmap, error) {
+	rc, err := res.Open(resource)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	desc, err := ParseBMFont(rc)
+	if err != nil {
+		return nil, &res.DecodeError{Resource: resource, Format: "bmfont", Err: err}
+	}
+	dir := res.Dir(resource)
+	pages := make([]*ebiten.Image, len(desc.Pages))
+	for idx, p := range desc.Pages {
+		pages[idx], err = res.Image(res.Join(dir, p))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewBitmap(desc, pages), nil
+}
+
+func NewBitmap(desc *BMFont, pages []*ebiten.Image) *Bitmap {
+	b := &Bitmap{
+		size:       float64(desc.Size),
+		lineHeight: float64(desc.LineHeight),
+		scale:      1,
+		glyphs:     map[rune]glyph{},
+		kerning:    map[[2]rune]float64{},
+	}
+	for _, c := range desc.Chars {
+		if c.Page < 0 || c.Page >= len(pages) {
+			continue
+		}
+		rect := image.Rect(c.X, c.Y, c.X+c.Width, c.Y+c.Height)
+		b.glyphs[c.ID] = glyph{
+			image:    pages[c.Page].SubImage(rect).(*ebiten.Image),
+			xOffset:  float64(c.XOffset),
+			yOffset:  float64(c.YOffset),
+			xAdvance: float64(c.XAdvance),
+		}
+	}
+	for _, k := range desc.Kernings {
+		b.kerning[[2]rune{k.First, k.Second}] = float64(k.Amount)
+	}
+	return b
+}
+
+// Bitmap is a face drawing pre rendered glyphs, size variants are scaled.
+type Bitmap struct {
+	size       float64
+	lineHeight float64
+	scale      float64
+	glyphs     map[rune]glyph
+	kerning    map[[2]rune]float64
+}
+
+type glyph struct {
+	image    *ebiten.Image
+	xOffset  float64
+	yOffset  float64
+	xAdvance float64
+}
+
+// WithSize returns a variant sharing the glyphs, scaled to size.
+func (b *Bitmap) WithSize(size float64) *Bitmap {
+	v := *b
+	if b.size > 0 {
+		v.scale = size / b.size
+	}
+	return &v
+}
+
+func (b *Bitmap) LineHeight() float64 {
+	return b.lineHeight * b.scale
+}
+
+func (b *Bitmap) Measure(s string) (float64, float64) {
+	w, h := 0.0, 0.0
+	b.layout(s, func(g glyph, x, y float64) {
+		w = math.Max(w, x+g.xAdvance*b.scale)
+		h = y + b.LineHeight()
+	})
+	return w, h
+}
+
+func (b *Bitmap) Draw(dst *ebiten.Image, s string, op *ebiten.DrawImageOptions) {
+	gop := &ebiten.DrawImageOptions{}
+	if op != nil {
+		*gop = *op
+	}
+	b.layout(s, func(g glyph, x, y float64) {
+		if g.image == nil {
+			return
+		}
+		gop.GeoM.Reset()
+		gop.GeoM.Scale(b.scale, b.scale)
+		gop.GeoM.Translate(x+g.xOffset*b.scale, y+g.yOffset*b.scale)
+		if op != nil {
+			gop.GeoM.Concat(op.GeoM)
+		}
+		dst.DrawImage(g.image, gop)
+	})
+}
+
+func (b *Bitmap) layout(s string, fn func(g glyph, x, y float64)) {
+	x, y := 0.0, 0.0
+	prev := rune(-1)
+	for _, r := range s {
+		if r == '\n' {
+			x, prev = 0, -1
+			y += b.LineHeight()
+			continue
+		}
+		g, ok := b.glyphs[r]
+		if !ok {
+			if g, ok = b.glyphs['?']; !ok {
+				continue
+			}
+		}
+		x += b.kerning[[2]rune{prev, r}] * b.scale
+		fn(g, x, y)
+		x += g.xAdvance * b.scale
+		prev = r
+	}
+}
@@ -0,0 +1,163 @@
+package font
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// BMFont is the descriptor of an AngelCode bitmap font in text format (.fnt).
+type BMFont struct {
+	Face       string
+	Size       int
+	LineHeight int
+	Base       int
+	Pages      []string
+	Chars      []Char
+	Kernings   []Kerning
+}
+
+type Char struct {
+	ID       rune
+	X        int
+	Y        int
+	Width    int
+	Height   int
+	XOffset  int
+	YOffset  int
+	XAdvance int
+	Page     int
+}
+
+type Kerning struct {
+	First  rune
+	Second rune
+	Amount int
+}
+
+// maxPages bounds the page ids of a descriptor whose common line does not
+// declare a page count.
+const maxPages = 256
+
+func ParseBMFont(in io.Reader) (*BMFont, error) {
+	f := &BMFont{}
+	s := bufio.NewScanner(in)
+	line := 0
+	pages := maxPages
+	for s.Scan() {
+		line++
+		tag, attrs := splitTag(s.Text())
+		var err error
+		switch tag {
+		case "info":
+			f.Face = attrs["face"]
+			f.Size, err = atoi(attrs, "size")
+			if f.Size < 0 {
+				f.Size = -f.Size
+			}
+		case "common":
+			f.LineHeight, err = atoi(attrs, "lineHeight")
+			if err == nil {
+				f.Base, err = atoi(attrs, "base")
+			}
+			if n := 0; err == nil {
+				n, err = atoi(attrs, "pages")
+				if n > 0 && n < maxPages {
+					pages = n
+				}
+			}
+		case "page":
+			id := 0
+			id, err = atoi(attrs, "id")
+			if err == nil && (id < 0 || id >= pages) {
+				err = fmt.Errorf("page id %d out of range [0,%d)", id, pages)
+			}
+			if err == nil {
+				for len(f.Pages) <= id {
+					f.Pages = append(f.Pages, "")
+				}
+				f.Pages[id] = attrs["file"]
+			}
+		case "char":
+			c := Char{}
+			id := 0
+			id, err = atoi(attrs, "id")
+			c.ID = rune(id)
+			for _, v := range []struct {
+				key string
+				val *int
+			}{
+				{"x", &c.X}, {"y", &c.Y}, {"width", &c.Width}, {"height", &c.Height},
+				{"xoffset", &c.XOffset}, {"yoffset", &c.YOffset}, {"xadvance", &c.XAdvance}, {"page", &c.Page},
+			} {
+				if err == nil {
+					*v.val, err = atoi(attrs, v.key)
+				}
+			}
+			f.Chars = append(f.Chars, c)
+		case "kerning":
+			k := Kerning{}
+			first, second := 0, 0
+			first, err = atoi(attrs, "first")
+			if err == nil {
+				second, err = atoi(attrs, "second")
+			}
+			if err == nil {
+				k.Amount, err = atoi(attrs, "amount")
+			}
+			k.First, k.Second = rune(first), rune(second)
+			f.Kernings = append(f.Kernings, k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bmfont line %d: %w", line, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if f.LineHeight == 0 || len(f.Pages) == 0 {
+		return nil, fmt.Errorf("bmfont: missing common or page definition")
+	}
+	return f, nil
+}
+
+// splitTag splits `char id=65 x="1"` into the tag and its attributes,
+// quoted values may contain spaces.
+func splitTag(line string) (string, map[string]string) {
+	line = strings.TrimSpace(line)
+	tag, rest, _ := strings.Cut(line, " ")
+	attrs := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		var val string
+		if strings.HasPrefix(after, `"`) {
+			end := strings.Index(after[1:], `"`)
+			if end < 0 {
+				val, rest = after[1:], ""
+			} else {
+				val, rest = after[1:end+1], after[end+2:]
+			}
+		} else {
+			val, rest, _ = strings.Cut(after, " ")
+		}
+		attrs[key] = val
+	}
+	return tag, attrs
+}
+
+func atoi(attrs map[string]string, key string) (int, error) {
+	v, ok := attrs[key]
+	if !ok {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("attribute %s: %w", key, err)
+	}
+	return i, nil
+}
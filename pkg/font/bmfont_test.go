@@ -0,0 +1,65 @@
+package font
+
+import (
+	"strings"
+	"testing"
+)
+
+const testFnt = `info face="Pixel Font" size=-16 bold=0 italic=0 padding=0,0,0,0 spacing=1,1
+common lineHeight=18 base=14 scaleW=128 scaleH=128 pages=1 packed=0
+page id=0 file="pixel font_0.png"
+chars count=2
+char id=65   x=0     y=0     width=8     height=12    xoffset=0     yoffset=2     xadvance=9     page=0  chnl=15
+char id=86   x=9     y=0     width=8     height=12    xoffset=1     yoffset=2     xadvance=9     page=0  chnl=15
+kernings count=1
+kerning first=65  second=86  amount=-1
+`
+
+func TestParseBMFont(t *testing.T) {
+	f, err := ParseBMFont(strings.NewReader(testFnt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Face != "Pixel Font" || f.Size != 16 {
+		t.Errorf("unexpected info face=%q size=%d", f.Face, f.Size)
+	}
+	if f.LineHeight != 18 || f.Base != 14 {
+		t.Errorf("unexpected common lineHeight=%d base=%d", f.LineHeight, f.Base)
+	}
+	if len(f.Pages) != 1 || f.Pages[0] != "pixel font_0.png" {
+		t.Errorf("unexpected pages %q", f.Pages)
+	}
+	if len(f.Chars) != 2 {
+		t.Fatalf("expected 2 chars but got %d", len(f.Chars))
+	}
+	v := f.Chars[1]
+	if v.ID != 'V' || v.X != 9 || v.XOffset != 1 || v.XAdvance != 9 {
+		t.Errorf("unexpected char %+v", v)
+	}
+	if len(f.Kernings) != 1 || f.Kernings[0] != (Kerning{First: 'A', Second: 'V', Amount: -1}) {
+		t.Errorf("unexpected kernings %+v", f.Kernings)
+	}
+}
+
+func TestParseBMFontInvalid(t *testing.T) {
+	_, err := ParseBMFont(strings.NewReader("common lineHeight=abc\n"))
+	if err == nil {
+		t.Error("expected an error for an invalid number")
+	}
+	_, err = ParseBMFont(strings.NewReader("info size=12\n"))
+	if err == nil {
+		t.Error("expected an error for a missing common block")
+	}
+	_, err = ParseBMFont(strings.NewReader("common lineHeight=18 pages=1\npage id=-1 file=\"a.png\"\n"))
+	if err == nil {
+		t.Error("expected an error for a negative page id")
+	}
+	_, err = ParseBMFont(strings.NewReader("common lineHeight=18 pages=1\npage id=1 file=\"a.png\"\n"))
+	if err == nil {
+		t.Error("expected an error for a page id beyond the page count")
+	}
+	_, err = ParseBMFont(strings.NewReader("common lineHeight=18\npage id=2147483647 file=\"a.png\"\n"))
+	if err == nil {
+		t.Error("expected an error for a huge page id")
+	}
+}
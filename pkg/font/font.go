@@ -0,0 +1,163 @@
+package font
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Face draws text with the top left corner at the origin of op.GeoM.
+type Face interface {
+	Draw(dst *ebiten.Image, s string, op *ebiten.DrawImageOptions)
+	Measure(s string) (w, h float64)
+	LineHeight() float64
+}
+
+var cache = struct {
+	sync.Mutex
+	sources map[res.ID]*pending[*text.GoTextFaceSource]
+	bitmaps map[res.ID]*pending[*Bitmap]
+	faces   map[faceKey]Face
+}{
+	sources: map[res.ID]*pending[*text.GoTextFaceSource]{},
+	bitmaps: map[res.ID]*pending[*Bitmap]{},
+	faces:   map[faceKey]Face{},
+}
+
+type faceKey struct {
+	resource res.ID
+	size     float64
+}
+
+// pending is a font that is loaded once, concurrent loads of the same
+// resource wait for done.
+type pending[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// once loads the entry for id with load unless another goroutine does,
+// without holding the cache lock during I/O. Failed loads are forgotten so
+// they can be retried. Resources without identity are loaded every time.
+func once[T any](m map[res.ID]*pending[T], id res.ID, cached bool, load func() (T, error)) (T, error) {
+	if !cached {
+		return load()
+	}
+	cache.Lock()
+	p, ok := m[id]
+	if ok {
+		cache.Unlock()
+		<-p.done
+		return p.value, p.err
+	}
+	p = &pending[T]{done: make(chan struct{})}
+	m[id] = p
+	cache.Unlock()
+
+	p.value, p.err = load()
+	if p.err != nil {
+		cache.Lock()
+		delete(m, id)
+		cache.Unlock()
+	}
+	close(p.done)
+	return p.value, p.err
+}
+
+// Load returns a face of the given size for a .ttf, .otf or .fnt resource.
+// Fonts are loaded once, every size variant shares the loaded font. Fonts
+// are cached by resource, the same path in different file systems is
+// loaded separately. A size of zero uses the native size of bitmap fonts.
+func Load(r res.Resource, size float64) (Face, error) {
+	id, cached := res.Identity(r)
+	key := faceKey{resource: id, size: size}
+	if cached {
+		cache.Lock()
+		f, ok := cache.faces[key]
+		cache.Unlock()
+		if ok {
+			return f, nil
+		}
+	}
+
+	var face Face
+	switch ext := res.Ext(r); ext {
+	case ".ttf", ".otf":
+		src, err := once(cache.sources, id, cached, func() (*text.GoTextFaceSource, error) {
+			return res.FontSource(r)
+		})
+		if err != nil {
+			return nil, err
+		}
+		face = NewTrueType(src, size)
+	case ".fnt":
+		b, err := once(cache.bitmaps, id, cached, func() (*Bitmap, error) {
+			return LoadBitmap(r)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if size != 0 {
+			b = b.WithSize(size)
+		}
+		face = b
+	default:
+		return nil, &res.DecodeError{Resource: r, Format: "font", Err: fmt.Errorf("unsupported extension %q", ext)}
+	}
+	if !cached {
+		return face, nil
+	}
+	cache.Lock()
+	defer cache.Unlock()
+	if f, ok := cache.faces[key]; ok {
+		// a concurrent Load created the face first
+		return f, nil
+	}
+	cache.faces[key] = face
+	return face, nil
+}
+
+func NewTrueType(src *text.GoTextFaceSource, size float64) *TrueType {
+	return &TrueType{
+		face: &text.GoTextFace{Source: src, Size: size},
+	}
+}
+
+// TrueType adapts a text/v2 face.
+type TrueType struct {
+	face *text.GoTextFace
+}
+
+func (t *TrueType) TextFace() *text.GoTextFace {
+	return t.face
+}
+
+func (t *TrueType) LineHeight() float64 {
+	m := t.face.Metrics()
+	return m.HAscent + m.HDescent + m.HLineGap
+}
+
+func (t *TrueType) Measure(s string) (float64, float64) {
+	return text.Measure(s, t.face, t.LineHeight())
+}
+
+func (t *TrueType) Draw(dst *ebiten.Image, s string, op *ebiten.DrawImageOptions) {
+	top := &text.DrawOptions{}
+	if op != nil {
+		top.DrawImageOptions = *op
+	}
+	top.LineSpacing = t.LineHeight()
+	text.Draw(dst, s, t.face, top)
+}
+
+// Draw draws s at x, y with the top left corner as origin.
+func Draw(dst *ebiten.Image, s string, face Face, x, y float64, colorScale ebiten.ColorScale) {
+	op := &ebiten.DrawImageOptions{ColorScale: colorScale}
+	op.GeoM.Translate(x, y)
+	face.Draw(dst, s, op)
+}
@@ -0,0 +1,103 @@
+package font
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+)
+
+const (
+	smallFnt = "info size=8\ncommon lineHeight=10 base=8\npage id=0 file=\"page.png\"\n"
+	largeFnt = "info size=16\ncommon lineHeight=20 base=16\npage id=0 file=\"page.png\"\n"
+)
+
+func fontFS(t *testing.T, fnt string) fstest.MapFS {
+	t.Helper()
+	var page bytes.Buffer
+	if err := png.Encode(&page, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	return fstest.MapFS{
+		"font.fnt": {Data: []byte(fnt)},
+		"page.png": {Data: page.Bytes()},
+	}
+}
+
+type countFS struct {
+	fs.FS
+	opens atomic.Int32
+}
+
+func (c *countFS) Open(name string) (fs.File, error) {
+	c.opens.Add(1)
+	return c.FS.Open(name)
+}
+
+func TestLoadCacheKeysFS(t *testing.T) {
+	small := fontFS(t, smallFnt)
+	large := fontFS(t, largeFnt)
+	a, err := Load(res.FromFS(small, "font.fnt"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Load(res.FromFS(large, "font.fnt"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.LineHeight() != 10 || b.LineHeight() != 20 {
+		t.Errorf("line heights = %v, %v, want 10, 20", a.LineHeight(), b.LineHeight())
+	}
+	again, err := Load(res.FromFS(small, "font.fnt"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != a {
+		t.Error("second Load of the same resource returned a new face")
+	}
+}
+
+func TestLoadCacheOnce(t *testing.T) {
+	fsys := &countFS{FS: fontFS(t, largeFnt)}
+	r := res.FromFS(fsys, "font.fnt")
+	var wg sync.WaitGroup
+	for idx := range 8 {
+		wg.Go(func() {
+			if _, err := Load(r, float64(8+idx%2*8)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+	// the descriptor and its page
+	if n := fsys.opens.Load(); n != 2 {
+		t.Errorf("font opened %d files, want 2", n)
+	}
+	f, err := Load(r, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := f.LineHeight(); h != 10 {
+		t.Errorf("line height of size 8 = %v, want 10", h)
+	}
+}
+
+func TestLoadCacheRetry(t *testing.T) {
+	fsys := fontFS(t, smallFnt)
+	fnt := fsys["font.fnt"]
+	delete(fsys, "font.fnt")
+	r := res.FromFS(fsys, "font.fnt")
+	if _, err := Load(r, 0); err == nil {
+		t.Fatal("expected an error for a missing font")
+	}
+	fsys["font.fnt"] = fnt
+	if _, err := Load(r, 0); err != nil {
+		t.Errorf("Load after the font was added: %s", err)
+	}
+}
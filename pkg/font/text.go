@@ -0,0 +1,60 @@
+package font
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func NewText(face Face, value string) *Text {
+	return &Text{
+		Face:  face,
+		Value: value,
+	}
+}
+
+// Text is a drawable string, with a Shader set the text is rendered into a
+// buffer first and drawn through the shader like a sprite frame.
+type Text struct {
+	Face   Face
+	Value  string
+	Shader Shader
+	buffer *ebiten.Image
+}
+
+func (t *Text) Update(dt time.Duration) {
+	if t.Shader != nil {
+		t.Shader.Update(dt)
+	}
+}
+
+func (t *Text) Draw(x, y float64, screen *ebiten.Image, colorScale ebiten.ColorScale) {
+	if t.Face == nil || t.Value == "" {
+		return
+	}
+	if t.Shader == nil {
+		Draw(screen, t.Value, t.Face, x, y, colorScale)
+		return
+	}
+
+	fw, fh := t.Face.Measure(t.Value)
+	w, h := int(math.Ceil(fw)), int(math.Ceil(fh))
+	if w == 0 || h == 0 {
+		return
+	}
+	if t.buffer == nil || t.buffer.Bounds().Dx() != w || t.buffer.Bounds().Dy() != h {
+		t.buffer = ebiten.NewImage(w, h)
+	}
+	t.buffer.Clear()
+	t.Face.Draw(t.buffer, t.Value, nil)
+
+	op := &ebiten.DrawRectShaderOptions{ColorScale: colorScale}
+	op.GeoM.Translate(x, y)
+	t.Shader.Draw(t.buffer, screen, op)
+}
+
+type Shader interface {
+	Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions)
+	Update(dt time.Duration)
+}
@@ -0,0 +1,98 @@
+package light
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+type Kind int
+
+const (
+	Point Kind = iota
+	Spot
+	Directional
+)
+
+// Falloff is the curve of the light intensity over the radius.
+type Falloff int
+
+const (
+	FalloffLinear Falloff = iota
+	FalloffQuadratic
+	FalloffSmooth
+	FalloffInverseSquare
+)
+
+// DefaultHeight is the distance of lights above the scene, it only matters
+// with normal maps.
+const DefaultHeight = 60
+
+// Light is a point, spot or directional light. Positions are in screen
+// space, or in world space when the System has a Camera.
+type Light struct {
+	Kind      Kind
+	Position  xmath.Vector2
+	Color     color.RGBA
+	Intensity float64
+	Radius    float64
+	Falloff   Falloff
+	// Direction is the angle in radians the light shines to, for spot and
+	// directional lights.
+	Direction float64
+	// Cone is the half angle of a spot light in radians, Softness the angle
+	// of the soft edge inside the cone.
+	Cone        float64
+	Softness    float64
+	Height      float64
+	CastShadows bool
+	Disabled    bool
+}
+
+func NewPoint(pos xmath.Vector2, radius float64, c color.RGBA) *Light {
+	return &Light{
+		Kind:        Point,
+		Position:    pos,
+		Color:       c,
+		Intensity:   1,
+		Radius:      radius,
+		Falloff:     FalloffQuadratic,
+		Height:      DefaultHeight,
+		CastShadows: true,
+	}
+}
+
+func NewSpot(pos xmath.Vector2, radius, direction, cone float64, c color.RGBA) *Light {
+	l := NewPoint(pos, radius, c)
+	l.Kind = Spot
+	l.Direction = direction
+	l.Cone = cone
+	l.Softness = cone / 4
+	return l
+}
+
+// NewDirectional returns a light shining everywhere from one direction,
+// like the sun.
+func NewDirectional(direction float64, c color.RGBA) *Light {
+	return &Light{
+		Kind:        Directional,
+		Color:       c,
+		Intensity:   1,
+		Direction:   direction,
+		Height:      DefaultHeight,
+		CastShadows: true,
+	}
+}
+
+func (l *Light) rgb() []float32 {
+	return []float32{
+		float32(float64(l.Color.R) / 255 * l.Intensity),
+		float32(float64(l.Color.G) / 255 * l.Intensity),
+		float32(float64(l.Color.B) / 255 * l.Intensity),
+	}
+}
+
+func (l *Light) cones() (outer, inner float32) {
+	return float32(math.Cos(l.Cone)), float32(math.Cos(math.Max(l.Cone-l.Softness, 0)))
+}
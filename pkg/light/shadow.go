@@ -0,0 +1,62 @@
+package light
+
+import (
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+// Occluder is a polygon casting shadows, in the same space as the lights.
+type Occluder struct {
+	Polygon  xmath.Polygon
+	Position xmath.Vector2
+	Rotation float64
+}
+
+func (o *Occluder) points() []xmath.Vector2 {
+	return o.Polygon.Transform(o.Position, o.Rotation)
+}
+
+// ShadowQuads returns a quad for every edge of points facing away from a
+// point light at from, stretched length away from it. The occluder itself
+// stays lit.
+func ShadowQuads(from xmath.Vector2, points []xmath.Vector2, length float64) [][4]xmath.Vector2 {
+	return shadowQuads(points, func(p xmath.Vector2) xmath.Vector2 {
+		return p.Add(p.Sub(from).Normalize().Mul(length))
+	}, func(mid xmath.Vector2) xmath.Vector2 {
+		return mid.Sub(from)
+	})
+}
+
+// ShadowQuadsDir is ShadowQuads for a directional light shining to dir.
+func ShadowQuadsDir(dir xmath.Vector2, points []xmath.Vector2, length float64) [][4]xmath.Vector2 {
+	dir = dir.Normalize()
+	return shadowQuads(points, func(p xmath.Vector2) xmath.Vector2 {
+		return p.Add(dir.Mul(length))
+	}, func(xmath.Vector2) xmath.Vector2 {
+		return dir
+	})
+}
+
+func shadowQuads(points []xmath.Vector2, project func(xmath.Vector2) xmath.Vector2, toEdge func(mid xmath.Vector2) xmath.Vector2) [][4]xmath.Vector2 {
+	if len(points) < 2 {
+		return nil
+	}
+	area := 0.0
+	for idx, a := range points {
+		b := points[(idx+1)%len(points)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	var quads [][4]xmath.Vector2
+	for idx, a := range points {
+		b := points[(idx+1)%len(points)]
+		d := b.Sub(a)
+		normal := xmath.Vector2{X: d.Y, Y: -d.X}
+		if area < 0 {
+			normal = normal.Mul(-1)
+		}
+		if normal.Dot(toEdge(xmath.Lerp(a, b, 0.5))) <= 0 {
+			continue
+		}
+		quads = append(quads, [4]xmath.Vector2{a, b, project(b), project(a)})
+	}
+	return quads
+}
@@ -0,0 +1,45 @@
+package light
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+func TestShadowQuads(t *testing.T) {
+	box := xmath.Polygon{Points: []xmath.Vector2{{X: -10, Y: -10}, {X: 10, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10}}}
+	o := &Occluder{Polygon: box, Position: xmath.Vector2{X: 100, Y: 0}}
+
+	quads := ShadowQuads(xmath.Vector2{}, o.points(), 50)
+	if len(quads) != 3 {
+		t.Fatalf("expected all but the edge facing the light to cast a shadow but got %d quads", len(quads))
+	}
+	back := 0
+	for _, q := range quads {
+		if q[0].X == 90 && q[1].X == 90 {
+			t.Errorf("the edge facing the light must not cast a shadow %v", q)
+		}
+		if q[0].X == 110 && q[1].X == 110 {
+			back++
+		}
+		if d := q[3].Distance(q[0]); d < 50-1e-9 || d > 50+1e-9 {
+			t.Errorf("expected the quad to be stretched by 50 but got %f", d)
+		}
+	}
+
+	if back != 1 {
+		t.Errorf("expected the back edge at x=110 to cast a shadow but got %v", quads)
+	}
+
+	reversed := slices.Clone(o.points())
+	slices.Reverse(reversed)
+	if len(ShadowQuads(xmath.Vector2{}, reversed, 50)) != 3 {
+		t.Error("the winding of the polygon must not matter")
+	}
+
+	down := ShadowQuadsDir(xmath.Vector2{Y: 1}, o.points(), 30)
+	if len(down) != 1 || down[0][2].Y != 40 {
+		t.Errorf("expected the bottom edge to cast a shadow 30 down but got %v", down)
+	}
+}
@@ -0,0 +1,240 @@
+package light
+
+import (
+	"embed"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	//go:embed kage/*.kage
+	shaders embed.FS
+
+	// FlatNormal is the normal map color of a surface facing the viewer.
+	FlatNormal = color.RGBA{128, 128, 255, 255}
+)
+
+// New creates a system with a dim ambient light. The shaders are compiled
+// with the shared loader of package kage, every system uses the same ones.
+func New() (*System, error) {
+	light, _, err := kage.Shared().LoadFS(shaders, "kage/light.kage", nil)
+	if err != nil {
+		return nil, err
+	}
+	composite, _, err := kage.Shared().LoadFS(shaders, "kage/composite.kage", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &System{
+		Ambient:   color.RGBA{40, 40, 50, 255},
+		light:     light,
+		composite: composite,
+	}, nil
+}
+
+// System renders lights into a light buffer and multiplies the scene with
+// it. It is a shader.Shader, so it can be a pass of a shader.Pipeline:
+//
+//	lights.Begin(w, h)
+//	// draw the scene and sprite normal maps with DrawNormal
+//	lights.Draw(scene, screen, &ebiten.DrawRectShaderOptions{})
+type System struct {
+	Ambient   color.RGBA
+	Lights    []*Light
+	Occluders []*Occluder
+	// Camera converts lights and occluders from world to screen space.
+	Camera    *xmath.Camera
+	light     *ebiten.Shader
+	composite *ebiten.Shader
+	buffer    *ebiten.Image
+	normals   *ebiten.Image
+	shadow    *ebiten.Image
+	white     *ebiten.Image
+	normal    bool
+}
+
+func (s *System) Add(l *Light) *Light {
+	s.Lights = append(s.Lights, l)
+	return l
+}
+
+func (s *System) Remove(l *Light) {
+	for idx, other := range s.Lights {
+		if other == l {
+			s.Lights = append(s.Lights[:idx], s.Lights[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (s *System) AddOccluder(o *Occluder) *Occluder {
+	s.Occluders = append(s.Occluders, o)
+	return o
+}
+
+// Begin prepares the buffers for a frame of w x h and clears the normals.
+func (s *System) Begin(w, h int) {
+	s.resize(w, h)
+	s.normals.Fill(FlatNormal)
+	s.normal = false
+}
+
+// DrawNormal draws a normal map into the normal buffer, use the same
+// options as for the color image of the sprite.
+func (s *System) DrawNormal(img *ebiten.Image, op *ebiten.DrawImageOptions) {
+	s.normals.DrawImage(img, op)
+	s.normal = true
+}
+
+// Normals returns the normal buffer to draw into directly.
+func (s *System) Normals() *ebiten.Image {
+	s.normal = true
+	return s.normals
+}
+
+// Buffer returns the light buffer of the last frame.
+func (s *System) Buffer() *ebiten.Image {
+	return s.buffer
+}
+
+func (s *System) resize(w, h int) {
+	if s.buffer != nil && s.buffer.Bounds().Dx() == w && s.buffer.Bounds().Dy() == h {
+		return
+	}
+	for _, img := range []*ebiten.Image{s.buffer, s.normals, s.shadow} {
+		if img != nil {
+			img.Deallocate()
+		}
+	}
+	s.buffer = ebiten.NewImage(w, h)
+	s.normals = ebiten.NewImage(w, h)
+	s.normals.Fill(FlatNormal)
+	s.shadow = ebiten.NewImage(w, h)
+	if s.white == nil {
+		s.white = ebiten.NewImage(3, 3)
+		s.white.Fill(color.White)
+	}
+}
+
+func (s *System) Update(dt time.Duration) {}
+
+// Draw renders the light buffer for srcImage and draws the lit scene to
+// screen. srcImage must have the size passed to Begin, without a Begin the
+// first Draw sets it.
+func (s *System) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
+	w, h := srcImage.Bounds().Dx(), srcImage.Bounds().Dy()
+	if s.buffer == nil {
+		s.Begin(w, h)
+	}
+	if b := s.buffer.Bounds(); b.Dx() != w || b.Dy() != h {
+		panic(fmt.Sprintf("light: source image is %dx%d but Begin was called with %dx%d", w, h, b.Dx(), b.Dy()))
+	}
+	s.renderLights(w, h)
+
+	if op == nil {
+		op = &ebiten.DrawRectShaderOptions{}
+	}
+	op.Images[0] = srcImage
+	op.Images[1] = s.buffer
+	screen.DrawRectShader(w, h, s.composite, op)
+}
+
+func (s *System) renderLights(w, h int) {
+	s.buffer.Fill(s.Ambient)
+	zoom, rot := 1.0, 0.0
+	if s.Camera != nil {
+		zoom, rot = s.Camera.Zoom, s.Camera.Rotation
+		if zoom == 0 {
+			zoom = 1
+		}
+	}
+	length := float64(w+h) * 2
+	occluders := make([][]xmath.Vector2, len(s.Occluders))
+	for idx, o := range s.Occluders {
+		occluders[idx] = s.toScreen(o.points())
+	}
+
+	useNormals := float32(0)
+	if s.normal {
+		useNormals = 1
+	}
+	for _, l := range s.Lights {
+		if l.Disabled {
+			continue
+		}
+		pos := s.toScreen([]xmath.Vector2{l.Position})[0]
+		dir := xmath.FromAngle(l.Direction - rot)
+
+		s.shadow.Clear()
+		if l.CastShadows {
+			for _, points := range occluders {
+				var quads [][4]xmath.Vector2
+				if l.Kind == Directional {
+					quads = ShadowQuadsDir(dir, points, length)
+				} else {
+					quads = ShadowQuads(pos, points, length)
+				}
+				s.fillQuads(quads)
+			}
+		}
+
+		outer, inner := l.cones()
+		op := &ebiten.DrawRectShaderOptions{}
+		op.Blend = ebiten.BlendLighter
+		op.Images[0] = s.normals
+		op.Images[1] = s.shadow
+		op.Uniforms = map[string]any{
+			"LightPos":   []float32{float32(pos.X), float32(pos.Y)},
+			"LightColor": l.rgb(),
+			"Radius":     float32(math.Max(l.Radius*zoom, 1)),
+			"Falloff":    float32(l.Falloff),
+			"Kind":       float32(l.Kind),
+			"Direction":  []float32{float32(dir.X), float32(dir.Y)},
+			"CosOuter":   outer,
+			"CosInner":   inner,
+			"Height":     float32(l.Height * zoom),
+			"UseNormals": useNormals,
+		}
+		s.buffer.DrawRectShader(w, h, s.light, op)
+	}
+}
+
+func (s *System) toScreen(points []xmath.Vector2) []xmath.Vector2 {
+	if s.Camera == nil {
+		return points
+	}
+	out := make([]xmath.Vector2, len(points))
+	for idx, p := range points {
+		out[idx] = s.Camera.WorldToScreen(p)
+	}
+	return out
+}
+
+func (s *System) fillQuads(quads [][4]xmath.Vector2) {
+	if len(quads) == 0 {
+		return
+	}
+	vertices := make([]ebiten.Vertex, 0, len(quads)*4)
+	indices := make([]uint16, 0, len(quads)*6)
+	for _, q := range quads {
+		base := uint16(len(vertices))
+		for _, p := range q {
+			vertices = append(vertices, ebiten.Vertex{
+				DstX: float32(p.X), DstY: float32(p.Y),
+				SrcX: 1, SrcY: 1,
+				ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+			})
+		}
+		indices = append(indices, base, base+1, base+2, base, base+2, base+3)
+	}
+	src := s.white.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+	s.shadow.DrawTriangles(vertices, indices, src, nil)
+}
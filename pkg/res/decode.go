@@ -0,0 +1,68 @@
+package res
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Defaulter is implemented by types that set default values, SetDefaults
+// is called before decoding so the file only overrides what it contains.
+type Defaulter interface {
+	SetDefaults()
+}
+
+// Validator is implemented by types that check their values after decoding.
+type Validator interface {
+	Validate() error
+}
+
+// Decode reads a .json, .yaml, .yml or .toml resource into a T. Hooks run
+// after Validate and may check or adjust the value.
+//
+//	tuning, err := res.Decode[Tuning](res.MustParse("data/tuning.toml"))
+func Decode[T any](r Resource, hooks ...func(*T) error) (T, error) {
+	var v T
+	err := DecodeInto(r, &v, hooks...)
+	return v, err
+}
+
+// DecodeInto is like Decode but decodes into v, fields not present in the
+// resource keep their current value unless v implements Defaulter.
+func DecodeInto[T any](r Resource, v *T, hooks ...func(*T) error) error {
+	data, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if d, ok := any(v).(Defaulter); ok {
+		d.SetDefaults()
+	}
+	ext := Ext(r)
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	case ".toml":
+		err = toml.Unmarshal(data, v)
+	default:
+		err = fmt.Errorf("unsupported extension %q", ext)
+	}
+	if err != nil {
+		return &DecodeError{Resource: r, Format: strings.TrimPrefix(ext, "."), Err: err}
+	}
+	if val, ok := any(v).(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return &ValidationError{Resource: r, Err: err}
+		}
+	}
+	for _, hook := range hooks {
+		if err := hook(v); err != nil {
+			return &ValidationError{Resource: r, Err: err}
+		}
+	}
+	return nil
+}
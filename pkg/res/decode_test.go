@@ -0,0 +1,114 @@
+package res
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type tuning struct {
+	Speed   float64 `json:"speed" yaml:"speed" toml:"speed"`
+	Jump    float64 `json:"jump" yaml:"jump" toml:"jump"`
+	Enemies []string
+}
+
+func (t *tuning) SetDefaults() {
+	t.Jump = 10
+}
+
+func (t *tuning) Validate() error {
+	if t.Speed <= 0 {
+		return errors.New("speed must be positive")
+	}
+	return nil
+}
+
+func TestDecode(t *testing.T) {
+	fsys := fstest.MapFS{
+		"tuning.json": {Data: []byte(`{"speed": 2.5, "Enemies": ["bat"]}`)},
+		"tuning.yaml": {Data: []byte("speed: 2.5\nenemies: [bat]\n")},
+		"tuning.toml": {Data: []byte("speed = 2.5\nEnemies = [\"bat\"]\n")},
+	}
+	for name := range fsys {
+		v, err := Decode[tuning](FromFS(fsys, name))
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if v.Speed != 2.5 || v.Jump != 10 || len(v.Enemies) != 1 {
+			t.Errorf("%s: unexpected value %+v", name, v)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"broken.json":  {Data: []byte(`{"speed": `)},
+		"invalid.json": {Data: []byte(`{"speed": 0}`)},
+		"tuning.ini":   {Data: []byte(`speed=1`)},
+		"hook.json":    {Data: []byte(`{"speed": 1}`)},
+	}
+	var de *DecodeError
+	if _, err := Decode[tuning](FromFS(fsys, "broken.json")); !errors.As(err, &de) || de.Format != "json" {
+		t.Errorf("expected json decode error but got %v", err)
+	}
+	if _, err := Decode[tuning](FromFS(fsys, "tuning.ini")); !errors.As(err, &de) {
+		t.Errorf("expected decode error for unknown extension but got %v", err)
+	}
+	var ve *ValidationError
+	if _, err := Decode[tuning](FromFS(fsys, "invalid.json")); !errors.As(err, &ve) {
+		t.Errorf("expected validation error but got %v", err)
+	}
+	tooSlow := func(v *tuning) error {
+		if v.Speed < 2 {
+			return errors.New("too slow")
+		}
+		return nil
+	}
+	if _, err := Decode(FromFS(fsys, "hook.json"), tooSlow); !errors.As(err, &ve) {
+		t.Errorf("expected validation error from hook but got %v", err)
+	}
+}
+
+func TestLive(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tuning.json")
+	write := func(data string, mod time.Time) {
+		if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	write(`{"speed": 1}`, now)
+
+	live, err := NewLive[tuning](MustParse(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	live.SetInterval(100 * time.Millisecond)
+	reloaded := 0
+	live.OnReload = func(v tuning) { reloaded++ }
+
+	write(`{"speed": 3}`, now.Add(time.Second))
+	live.Update(50 * time.Millisecond)
+	if live.Value().Speed != 1 {
+		t.Error("value must not change before the interval elapsed")
+	}
+	live.Update(50 * time.Millisecond)
+	if live.Value().Speed != 3 || reloaded != 1 {
+		t.Errorf("expected reloaded speed 3 but got %f", live.Value().Speed)
+	}
+
+	write(`{"speed": 0}`, now.Add(2*time.Second))
+	live.Update(100 * time.Millisecond)
+	if live.Err() == nil {
+		t.Error("expected a validation error after an invalid edit")
+	}
+	if live.Value().Speed != 3 {
+		t.Error("a failed reload must keep the last valid value")
+	}
+}
@@ -0,0 +1,96 @@
+package res
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+// Error records a failed operation on a resource, e.g. a network failure
+// while opening or an io error while reading.
+type Error struct {
+	Op       string
+	Resource Resource
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("res: %s %s: %s", e.Op, e.Resource, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NotFoundError reports a missing resource. It matches fs.ErrNotExist with
+// errors.Is, for http resources Err holds the *HTTPStatusError.
+type NotFoundError struct {
+	Resource Resource
+	Err      error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("res: %s not found: %s", e.Resource, e.Err)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+// DecodeError reports a resource that was read but could not be decoded
+// into Format, e.g. "image", "shader" or "json".
+type DecodeError struct {
+	Resource Resource
+	Format   string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("res: decode %s %s: %s", e.Format, e.Resource, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError reports a decoded resource rejected by Validate or a hook.
+type ValidationError struct {
+	Resource Resource
+	Err      error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("res: invalid %s: %s", e.Resource, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusError is returned for responses with an unexpected status code.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("http status %s", e.Status)
+}
+
+func wrapErr(op string, r Resource, err error) error {
+	notFound := errors.Is(err, fs.ErrNotExist)
+	var se *HTTPStatusError
+	if errors.As(err, &se) {
+		notFound = se.StatusCode == http.StatusNotFound || se.StatusCode == http.StatusGone
+	}
+	if notFound {
+		return &NotFoundError{Resource: r, Err: err}
+	}
+	return &Error{Op: op, Resource: r, Err: err}
+}
@@ -0,0 +1,79 @@
+package res
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	old := HTTP
+	HTTP = testBackend(srv)
+	defer func() { HTTP = old }()
+
+	for _, r := range []Resource{
+		MustParse(filepath.Join(t.TempDir(), "missing.png")),
+		FromFS(fstest.MapFS{}, "missing.png"),
+		MustParse(srv.URL + "/missing.png"),
+	} {
+		_, err := ReadAll(r)
+		var nf *NotFoundError
+		if !errors.As(err, &nf) {
+			t.Fatalf("%s: expected *NotFoundError but got %T: %v", r, err, err)
+		}
+		if nf.Resource.String() != r.String() {
+			t.Errorf("expected resource %s but got %s", r, nf.Resource)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s: expected error to match fs.ErrNotExist", r)
+		}
+		if !strings.Contains(err.Error(), r.String()) {
+			t.Errorf("expected resource in message: %s", err)
+		}
+	}
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	old := HTTP
+	HTTP = testBackend(srv)
+	defer func() { HTTP = old }()
+
+	_, err := Open(MustParse(srv.URL))
+	var se *HTTPStatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected *HTTPStatusError but got %T: %v", err, err)
+	}
+	if se.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status %d but got %d", http.StatusForbidden, se.StatusCode)
+	}
+	var nf *NotFoundError
+	if errors.As(err, &nf) {
+		t.Error("status 403 must not be reported as not found")
+	}
+}
+
+func TestStat(t *testing.T) {
+	fsys := fstest.MapFS{"a.kage": &fstest.MapFile{Data: []byte("package main")}}
+	info, err := Stat(FromFS(fsys, "a.kage"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len("package main")) {
+		t.Errorf("unexpected size %d", info.Size())
+	}
+	_, err = Stat(FromFS(fsys, "b.kage"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected not found but got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package res
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	logger "github.com/weakpixel/ebitenkiso/pkg/log"
+)
+
+// HTTP is the backend used by Open for http and https resources.
+var HTTP = NewHTTPBackend(nil)
+
+// NewHTTPBackend returns a backend with sensible defaults. A nil client
+// falls back to http.DefaultClient.
+func NewHTTPBackend(client *http.Client) *HTTPBackend {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPBackend{
+		Client:  client,
+		Timeout: 30 * time.Second,
+		Retries: 2,
+		Backoff: 250 * time.Millisecond,
+	}
+}
+
+// HTTPBackend fetches http resources. Failed requests are retried with an
+// exponential backoff on network errors and on 408, 429 and 5xx responses.
+// When a Cache is set, responses carrying an ETag or Last-Modified header
+// are stored and revalidated with conditional requests.
+type HTTPBackend struct {
+	Client *http.Client
+	// Timeout limits a single attempt, zero means no limit.
+	Timeout time.Duration
+	// Retries is the number of additional attempts after the first one.
+	Retries int
+	// Backoff is the wait before the first retry, it doubles on every retry.
+	Backoff time.Duration
+	Cache   Cache
+	// OnCacheError is called with the *Error of a failed Cache.Put, the
+	// fetched body is still returned. When nil the error is logged.
+	OnCacheError func(err error)
+}
+
+func (b *HTTPBackend) Open(ctx context.Context, url string) (io.ReadCloser, error) {
+	var cached *CacheEntry
+	if b.Cache != nil {
+		if e, ok := b.Cache.Get(url); ok {
+			cached = &e
+		}
+	}
+	var lastErr error
+	for attempt := 0; attempt <= b.Retries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, b.Backoff<<(attempt-1)); err != nil {
+				return nil, err
+			}
+		}
+		body, retry, err := b.fetch(ctx, url, cached)
+		if err == nil {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		lastErr = err
+		if !retry || ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+func (b *HTTPBackend) fetch(ctx context.Context, url string, cached *CacheEntry) ([]byte, bool, error) {
+	if b.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("http.NewRequest failed: %w", err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, true, fmt.Errorf("http.Get failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.Body, false, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, res.Body)
+		return nil, retryStatus(res.StatusCode), &HTTPStatusError{
+			URL:        url,
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+		}
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("io.ReadAll failed: %w", err)
+	}
+	if b.Cache != nil {
+		entry := CacheEntry{
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+			Body:         body,
+		}
+		if entry.ETag != "" || entry.LastModified != "" {
+			if err := b.Cache.Put(url, entry); err != nil {
+				b.cacheError(&Error{Op: "cache", Resource: Resource{p: url, isHttp: true}, Err: err})
+			}
+		}
+	}
+	return body, false, nil
+}
+
+func (b *HTTPBackend) cacheError(err error) {
+	if b.OnCacheError != nil {
+		b.OnCacheError(err)
+		return
+	}
+	logger.Errorf("%s", err)
+}
+
+func retryStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// CacheEntry is a cached http response body with its validators.
+type CacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"-"`
+}
+
+type Cache interface {
+	Get(url string) (CacheEntry, bool)
+	Put(url string, e CacheEntry) error
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]CacheEntry{}}
+}
+
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+func (c *MemoryCache) Get(url string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[url]
+	return e, ok
+}
+
+func (c *MemoryCache) Put(url string, e CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[url] = e
+	return nil
+}
+
+// NewDiskCache stores entries below dir, one body and one meta file per url.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{dir: dir}
+}
+
+type DiskCache struct {
+	dir string
+}
+
+func (c *DiskCache) file(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *DiskCache) Get(url string) (CacheEntry, bool) {
+	name := c.file(url)
+	meta, err := os.ReadFile(name + ".json")
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	e := CacheEntry{}
+	if err := json.Unmarshal(meta, &e); err != nil {
+		return CacheEntry{}, false
+	}
+	e.Body, err = os.ReadFile(name + ".body")
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	return e, true
+}
+
+func (c *DiskCache) Put(url string, e CacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("os.MkdirAll failed: %w", err)
+	}
+	meta, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("json.Marshal failed: %w", err)
+	}
+	name := c.file(url)
+	// the meta file is dropped first, so a failed write never pairs new
+	// validators with a stale body
+	os.Remove(name + ".json")
+	if err := os.WriteFile(name+".body", e.Body, 0o644); err != nil {
+		return fmt.Errorf("os.WriteFile failed: %w", err)
+	}
+	if err := os.WriteFile(name+".json", meta, 0o644); err != nil {
+		return fmt.Errorf("os.WriteFile failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package res
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testBackend(srv *httptest.Server) *HTTPBackend {
+	b := NewHTTPBackend(srv.Client())
+	b.Backoff = time.Millisecond
+	return b
+}
+
+func readString(t *testing.T, rc io.ReadCloser, err error) string {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("open failed: %s", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read failed: %s", err)
+	}
+	return string(data)
+}
+
+func TestHTTPRetry(t *testing.T) {
+	calls := atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	b := testBackend(srv)
+	rc, err := b.Open(context.Background(), srv.URL)
+	if got := readString(t, rc, err); got != "ok" {
+		t.Errorf("expected body %q but got %q", "ok", got)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests but got %d", calls.Load())
+	}
+}
+
+func TestHTTPNoRetryOnNotFound(t *testing.T) {
+	calls := atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := testBackend(srv).Open(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("expected an error for status 404")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 request but got %d", calls.Load())
+	}
+}
+
+func TestHTTPRevalidate(t *testing.T) {
+	calls := atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("payload"))
+	}))
+	defer srv.Close()
+
+	for _, cache := range []Cache{NewMemoryCache(), NewDiskCache(t.TempDir())} {
+		calls.Store(0)
+		b := testBackend(srv)
+		b.Cache = cache
+		for range 2 {
+			rc, err := b.Open(context.Background(), srv.URL)
+			if got := readString(t, rc, err); got != "payload" {
+				t.Errorf("%T: expected body %q but got %q", cache, "payload", got)
+			}
+		}
+		if calls.Load() != 2 {
+			t.Errorf("%T: expected 2 requests but got %d", cache, calls.Load())
+		}
+	}
+}
+
+func TestHTTPCacheError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("payload"))
+	}))
+	defer srv.Close()
+
+	// a file in place of the cache directory makes every Put fail
+	dir := filepath.Join(t.TempDir(), "cache")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b := testBackend(srv)
+	b.Cache = NewDiskCache(dir)
+	var cacheErr error
+	b.OnCacheError = func(err error) { cacheErr = err }
+	rc, err := b.Open(context.Background(), srv.URL)
+	if got := readString(t, rc, err); got != "payload" {
+		t.Errorf("expected body %q but got %q", "payload", got)
+	}
+	var resErr *Error
+	if !errors.As(cacheErr, &resErr) || resErr.Op != "cache" {
+		t.Errorf("expected a cache *Error but got %v", cacheErr)
+	}
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	b := testBackend(srv)
+	b.Timeout = 10 * time.Millisecond
+	b.Retries = 0
+	_, err := b.Open(context.Background(), srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded but got %v", err)
+	}
+}
+
+func TestHTTPContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := OpenContext(ctx, MustParse(srv.URL))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled but got %v", err)
+	}
+}
+
+func TestReadAllHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	old := HTTP
+	HTTP = testBackend(srv)
+	defer func() { HTTP = old }()
+
+	data, err := ReadAll(Join(MustParse(srv.URL), "sprites", "run.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "/sprites/run.json" {
+		t.Errorf("expected path %q but got %q", "/sprites/run.json", data)
+	}
+}
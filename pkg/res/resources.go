@@ -1,20 +1,24 @@
 package res
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
-	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	_ "image/jpeg"
 	_ "image/png"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 type Resource struct {
@@ -27,6 +31,40 @@ func (r Resource) String() string {
 	return r.p
 }
 
+// ID identifies the file a resource refers to and can key maps. The same
+// path in different file systems has different IDs.
+type ID struct {
+	fs     any
+	p      string
+	isHttp bool
+}
+
+type fsPointer struct {
+	t reflect.Type
+	p uintptr
+}
+
+// Identity returns the ID of r. It reports false for file systems that
+// cannot be compared and have no pointer identity, e.g. a struct holding a
+// map.
+func Identity(r Resource) (ID, bool) {
+	id := ID{p: r.p, isHttp: r.isHttp}
+	if r.fs == nil {
+		return id, true
+	}
+	v := reflect.ValueOf(r.fs)
+	switch {
+	case v.Comparable():
+		id.fs = r.fs
+	case v.Kind() == reflect.Map:
+		// e.g. fstest.MapFS, copies of a map share its storage
+		id.fs = fsPointer{t: v.Type(), p: v.Pointer()}
+	default:
+		return ID{}, false
+	}
+	return id, true
+}
+
 func Dir(r Resource) Resource {
 	if r.fs != nil {
 		return Resource{p: path.Dir(r.p), fs: r.fs}
@@ -54,45 +92,89 @@ func Join(r Resource, elem ...string) Resource {
 	return Resource{p: filepath.Join(parts...)}
 }
 
+// Ext returns the lower case file name extension of the resource, query
+// strings of http resources are ignored.
+func Ext(r Resource) string {
+	ext := ""
+	switch {
+	case r.fs != nil:
+		ext = path.Ext(r.p)
+	case r.isHttp:
+		u, _ := url.Parse(r.p)
+		ext = path.Ext(u.Path)
+	default:
+		ext = filepath.Ext(r.p)
+	}
+	return strings.ToLower(ext)
+}
+
 func ReadAll(r Resource) ([]byte, error) {
-	rc, err := Open(r)
+	return ReadAllContext(context.Background(), r)
+}
+
+func ReadAllContext(ctx context.Context, r Resource) ([]byte, error) {
+	rc, err := OpenContext(ctx, r)
 	if err != nil {
 		return nil, err
 	}
 	defer rc.Close()
 	data, err := io.ReadAll(rc)
 	if err != nil {
-		return nil, fmt.Errorf("io.ReadAll failed: %s", err)
+		return nil, &Error{Op: "read", Resource: r, Err: err}
 	}
 	return data, nil
 }
 
 func Open(r Resource) (io.ReadCloser, error) {
+	return OpenContext(context.Background(), r)
+}
+
+// OpenContext opens the resource, http resources are fetched through the
+// HTTP backend and honour the cancellation and deadline of ctx.
+func OpenContext(ctx context.Context, r Resource) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &Error{Op: "open", Resource: r, Err: err}
+	}
 	if r.fs != nil {
 		f, err := r.fs.Open(r.p)
 		if err != nil {
-			return nil, fmt.Errorf("fs.Open failed: %s", err)
+			return nil, wrapErr("open", r, err)
 		}
 		return f, nil
 	}
 	if r.isHttp {
-		res, err := http.Get(r.p)
+		rc, err := HTTP.Open(ctx, r.p)
 		if err != nil {
-			return nil, fmt.Errorf("http.Get failed: %s", err)
-		}
-		if res.StatusCode != 200 {
-			return nil, fmt.Errorf("http.Get: status %s", res.Status)
+			return nil, wrapErr("open", r, err)
 		}
-		return res.Body, nil
+		return rc, nil
 	}
 
 	f, err := os.Open(r.p)
 	if err != nil {
-		return nil, fmt.Errorf("os.Open failed: %s", err)
+		return nil, wrapErr("open", r, err)
 	}
 	return f, nil
 }
 
+// Stat describes the resource, it is not supported for http resources.
+func Stat(r Resource) (fs.FileInfo, error) {
+	var info fs.FileInfo
+	var err error
+	switch {
+	case r.fs != nil:
+		info, err = fs.Stat(r.fs, r.p)
+	case r.isHttp:
+		err = errors.ErrUnsupported
+	default:
+		info, err = os.Stat(r.p)
+	}
+	if err != nil {
+		return nil, wrapErr("stat", r, err)
+	}
+	return info, nil
+}
+
 func Image(r Resource) (*ebiten.Image, error) {
 	rc, err := Open(r)
 	if err != nil {
@@ -101,7 +183,7 @@ func Image(r Resource) (*ebiten.Image, error) {
 	defer rc.Close()
 	image, _, err := ebitenutil.NewImageFromReader(rc)
 	if err != nil {
-		return nil, fmt.Errorf("image.Decode failed: %s", err)
+		return nil, &DecodeError{Resource: r, Format: "image", Err: err}
 	}
 	return image, nil
 }
@@ -111,7 +193,26 @@ func Shader(r Resource) (*ebiten.Shader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ebiten.NewShader(data)
+	s, err := ebiten.NewShader(data)
+	if err != nil {
+		return nil, &DecodeError{Resource: r, Format: "shader", Err: err}
+	}
+	return s, nil
+}
+
+// FontSource loads a TrueType or OpenType font, faces of any size can be
+// created from the returned source.
+func FontSource(r Resource) (*text.GoTextFaceSource, error) {
+	rc, err := Open(r)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	src, err := text.NewGoTextFaceSource(rc)
+	if err != nil {
+		return nil, &DecodeError{Resource: r, Format: "font", Err: err}
+	}
+	return src, nil
 }
 
 func MustParse(s string) Resource {
@@ -125,13 +226,13 @@ func MustParse(s string) Resource {
 func Parse(s string) (Resource, error) {
 	u, err := url.Parse(s)
 	if err != nil {
-		return Resource{}, fmt.Errorf("url.Parse failed: %s", err)
+		return Resource{}, fmt.Errorf("url.Parse failed: %w", err)
 	}
 	switch u.Scheme {
 	case "res", "file":
 		fullPath, err := filepath.Abs(path.Join(u.Host, u.Path))
 		if err != nil {
-			return Resource{}, fmt.Errorf("filepath.Abs failed: %s", err)
+			return Resource{}, fmt.Errorf("filepath.Abs failed: %w", err)
 		}
 		p := filepath.FromSlash(fullPath)
 		return Resource{p: p}, nil
@@ -140,7 +241,7 @@ func Parse(s string) (Resource, error) {
 	default:
 		fullPath, err := filepath.Abs(filepath.FromSlash(s))
 		if err != nil {
-			return Resource{}, fmt.Errorf("filepath.Abs failed: %s", err)
+			return Resource{}, fmt.Errorf("filepath.Abs failed: %w", err)
 		}
 		return Resource{p: fullPath}, nil
 	}
@@ -0,0 +1,30 @@
+package res
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+func TestIdentity(t *testing.T) {
+	a := fstest.MapFS{"a.png": {}}
+	b := fstest.MapFS{"a.png": {}}
+	idA, ok := Identity(FromFS(a, "a.png"))
+	if !ok {
+		t.Fatal("MapFS has no identity")
+	}
+	idB, _ := Identity(FromFS(b, "a.png"))
+	if idA == idB {
+		t.Error("same path in different file systems has the same ID")
+	}
+	if again, _ := Identity(FromFS(a, "a.png")); again != idA {
+		t.Error("same resource has different IDs")
+	}
+	dir, _ := Identity(FromFS(os.DirFS("x"), "a.png"))
+	if other, _ := Identity(FromFS(os.DirFS("x"), "a.png")); dir != other {
+		t.Error("equal file systems have different IDs")
+	}
+	if _, ok := Identity(FromFS(struct{ fstest.MapFS }{a}, "a.png")); ok {
+		t.Error("struct holding a map has an identity")
+	}
+}
@@ -0,0 +1,113 @@
+package res
+
+import (
+	"time"
+)
+
+// NewWatcher polls the modification time of r every interval and calls
+// onChange when it differs from the last seen one. Resources that cannot be
+// stat'ed, like http resources, never report a change.
+func NewWatcher(r Resource, interval time.Duration, onChange func()) *Watcher {
+	w := &Watcher{
+		r:        r,
+		Interval: interval,
+		onChange: onChange,
+	}
+	if info, err := Stat(r); err == nil {
+		w.mod = info.ModTime()
+	}
+	return w
+}
+
+type Watcher struct {
+	r        Resource
+	Interval time.Duration
+	onChange func()
+	mod      time.Time
+	elapsed  time.Duration
+}
+
+func (w *Watcher) Resource() Resource {
+	return w.r
+}
+
+func (w *Watcher) Update(dt time.Duration) {
+	w.elapsed += dt
+	if w.elapsed < w.Interval {
+		return
+	}
+	w.elapsed = 0
+	if w.Check() && w.onChange != nil {
+		w.onChange()
+	}
+}
+
+// Check reports whether the resource changed since the last check.
+func (w *Watcher) Check() bool {
+	info, err := Stat(w.r)
+	if err != nil {
+		return false
+	}
+	if info.ModTime().Equal(w.mod) {
+		return false
+	}
+	w.mod = info.ModTime()
+	return true
+}
+
+// NewLive decodes r and keeps the value up to date while Update is called.
+func NewLive[T any](r Resource, hooks ...func(*T) error) (*Live[T], error) {
+	l := &Live[T]{hooks: hooks}
+	l.watcher = NewWatcher(r, time.Second, func() {
+		l.Reload()
+	})
+	if err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Live is a decoded resource that is reloaded when the file changes, e.g.
+// tuning tables edited while the game is running. A failed reload keeps
+// the last valid value.
+type Live[T any] struct {
+	value    T
+	hooks    []func(*T) error
+	watcher  *Watcher
+	err      error
+	OnReload func(v T)
+	OnError  func(err error)
+}
+
+func (l *Live[T]) Value() T {
+	return l.value
+}
+
+// Err returns the error of the last reload.
+func (l *Live[T]) Err() error {
+	return l.err
+}
+
+func (l *Live[T]) SetInterval(interval time.Duration) {
+	l.watcher.Interval = interval
+}
+
+func (l *Live[T]) Reload() error {
+	v, err := Decode(l.watcher.Resource(), l.hooks...)
+	l.err = err
+	if err != nil {
+		if l.OnError != nil {
+			l.OnError(err)
+		}
+		return err
+	}
+	l.value = v
+	if l.OnReload != nil {
+		l.OnReload(v)
+	}
+	return nil
+}
+
+func (l *Live[T]) Update(dt time.Duration) {
+	l.watcher.Update(dt)
+}
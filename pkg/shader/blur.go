@@ -1,78 +1,74 @@
 package shader
 
 import (
-	_ "embed"
 	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
-)
-
-var (
-	//go:embed kage/blur.kage
-	blurRaw []byte
-	blur    = mustNewShader(blurRaw)
-
-	//go:embed kage/abberation.kage
-	abbrRaw []byte
-	abbr    = mustNewShader(abbrRaw)
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
 
-	//go:embed kage/shape-renderer.kage
-	shapeRaw []byte
-	shape    = mustNewShader(shapeRaw)
-
-	//go:embed kage/blur-radial.kage
-	blurRadialRaw []byte
-	blurRadial    = mustNewShader(blurRadialRaw)
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
-func NewShapeRenderer() *BlurShader {
-	return &BlurShader{
-		shader: shape,
+func NewShapeRenderer() (*BlurShader, error) {
+	s, err := kage.Embedded("shape-renderer")
+	if err != nil {
+		return nil, err
 	}
+	return &BlurShader{
+		shader: s,
+	}, nil
 }
 
-func NewAbberation(offset float32) *BlurShader {
-	return &BlurShader{
-		shader:      abbr,
-		Offset:      offset,
-		PosX:        offset,
-		PosY:        offset,
-		mouseUpdate: false,
+func NewAbberation(offset float32) (*BlurShader, error) {
+	s, err := kage.Embedded("abberation")
+	if err != nil {
+		return nil, err
 	}
+	return &BlurShader{
+		shader: s,
+		Offset: offset,
+		PosX:   offset,
+		PosY:   offset,
+	}, nil
 }
 
-func NewBlurRadial(offset float32) *BlurShader {
-	return &BlurShader{
-		shader:      blurRadial,
-		Offset:      offset,
-		mouseUpdate: true,
+func NewBlurRadial(offset float32) (*BlurShader, error) {
+	s, err := kage.Embedded("blur-radial")
+	if err != nil {
+		return nil, err
 	}
+	return &BlurShader{
+		shader: s,
+		Offset: offset,
+	}, nil
 }
 
-func NewBlur(offset float32) *BlurShader {
-	return &BlurShader{
-		shader:      blur,
-		Offset:      offset,
-		mouseUpdate: true,
+func NewBlur(offset float32) (*BlurShader, error) {
+	s, err := kage.Embedded("blur")
+	if err != nil {
+		return nil, err
 	}
+	return &BlurShader{
+		shader: s,
+		Offset: offset,
+	}, nil
 }
 
 type BlurShader struct {
-	shader      *ebiten.Shader
-	PosX        float32
-	PosY        float32
-	Offset      float32
-	mouseUpdate bool
+	shader *ebiten.Shader
+	PosX   float32
+	PosY   float32
+	Offset float32
 }
 
-func (s *BlurShader) Update(dt time.Duration) {
-	if s.mouseUpdate {
-		x, y := ebiten.CursorPosition()
-		s.PosX = float32(x) + s.Offset
-		s.PosY = float32(y) + s.Offset
-	}
+// SetPosition moves the center of the effect to x, y in pixels of the
+// source image.
+func (s *BlurShader) SetPosition(x, y float32) {
+	s.PosX = x + s.Offset
+	s.PosY = y + s.Offset
 }
 
+func (s *BlurShader) Update(dt time.Duration) {}
+
 func (s *BlurShader) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
 	w, h := srcImage.Bounds().Dx(), srcImage.Bounds().Dy()
 	op.Uniforms = map[string]any{
@@ -0,0 +1,48 @@
+package shader
+
+import (
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Positioned is a shader with an effect center, e.g. a light or a radial
+// blur.
+type Positioned interface {
+	Shader
+	SetPosition(x, y float32)
+}
+
+// Follow moves s to position on every Update. With a Camera the position
+// is in world space and converted to screen space.
+func Follow(s Positioned, position func() xmath.Vector2) *Follower {
+	return &Follower{
+		Positioned: s,
+		Position:   position,
+	}
+}
+
+// FollowCursor moves s to the mouse cursor, as in the demos.
+func FollowCursor(s Positioned) *Follower {
+	return Follow(s, func() xmath.Vector2 {
+		x, y := ebiten.CursorPosition()
+		return xmath.Vector2{X: float64(x), Y: float64(y)}
+	})
+}
+
+type Follower struct {
+	Positioned
+	Position func() xmath.Vector2
+	Camera   *xmath.Camera
+}
+
+func (f *Follower) Update(dt time.Duration) {
+	p := f.Position()
+	if f.Camera != nil {
+		p = f.Camera.WorldToScreen(p)
+	}
+	f.SetPosition(float32(p.X), float32(p.Y))
+	f.Positioned.Update(dt)
+}
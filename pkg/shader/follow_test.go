@@ -0,0 +1,23 @@
+package shader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+func TestFollowCamera(t *testing.T) {
+	light, err := NewLight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torch := xmath.Vector2{X: 120, Y: 40}
+	f := Follow(light, func() xmath.Vector2 { return torch })
+	f.Camera = &xmath.Camera{Position: xmath.Vector2{X: 100, Y: 40}, Width: 320, Height: 180}
+
+	f.Update(time.Millisecond)
+	if light.PosX != 180 || light.PosY != 90 {
+		t.Errorf("expected the light at 180,90 on screen but got %f,%f", light.PosX, light.PosY)
+	}
+}
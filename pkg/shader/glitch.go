@@ -1,26 +1,25 @@
 package shader
 
 import (
-	_ "embed"
 	"math/rand"
 	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
-)
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
 
-var (
-	//go:embed kage/glitch.kage
-	glitch1Raw []byte
-	glitch1    = mustNewShader(glitch1Raw)
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
-func NewGlitchShader() *GlitchShader {
+func NewGlitchShader() (*GlitchShader, error) {
+	s, err := kage.Embedded("glitch")
+	if err != nil {
+		return nil, err
+	}
 	return &GlitchShader{
-		shader: glitch1,
+		shader: s,
 		MinVal: -200,
 		MaxVal: 200,
 		values: [2]float32{},
-	}
+	}, nil
 }
 
 type GlitchShader struct {
@@ -1,15 +1,14 @@
 package kage
 
 import (
-	"bufio"
 	"embed"
 	"fmt"
-	"io"
-	"io/fs"
-	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
-	"time"
+	"sync"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -18,118 +17,107 @@ var (
 	//go:embed *.kage partials/*.kage
 	content embed.FS
 
-	shaders = map[string]*ebiten.Shader{}
+	shared = sync.OnceValue(NewLoader)
 )
 
-func init() {
-	l := &loader{content, map[string]*shader{}}
-	list, err := content.ReadDir(".")
-	if err != nil {
-		panic(err)
-	}
-	for _, f := range list {
-		if !f.IsDir() {
-			key := toKey(f.Name())
-			shaders[key], err = l.Load(f.Name())
-			if err != nil {
-				panic(err)
-			}
-		}
-	}
-}
-
-func toKey(file string) string {
-	return strings.TrimSuffix(file, filepath.Ext(file))
-}
-
-var importRegex = regexp.MustCompile("//import:partial (.*)")
-
-type loader struct {
-	content fs.FS
-	cache   map[string]*shader
-}
-
-type shader struct {
-	shader *ebiten.Shader
-	mod    time.Time
+// Shared returns the loader of Embedded. Packages shipping their own
+// shaders compile them with it, so they are compiled once on first use
+// instead of at package init.
+func Shared() *Loader {
+	return shared()
 }
 
-func (l *loader) Load(name string) (*ebiten.Shader, error) {
-	f, err := l.content.Open(name)
-	if err != nil {
-		return nil, err
+// Embedded compiles one of the shaders shipped with this package. name is
+// the file name without extension, "blur@fast" selects a declared variant.
+func Embedded(name string) (*ebiten.Shader, error) {
+	file, variant, _ := strings.Cut(name, "@")
+	r := res.FromFS(content, file+".kage")
+	s, src, err := Shared().Load(r, nil)
+	if err != nil || variant == "" {
+		return s, err
 	}
-	stat, err := f.Stat()
-	if err != nil {
-		return nil, err
+	v, ok := src.Variant(variant)
+	if !ok {
+		return nil, fmt.Errorf("shader %q has no variant %q", file, variant)
 	}
+	s, _, err = Shared().Load(r, v.Defines)
+	return s, err
+}
 
-	mod := stat.ModTime()
-	if c, ok := l.cache[name]; ok && c != nil {
-		if c.mod == mod {
-			return c.shader, nil
-		}
-	}
+var positionRegex = regexp.MustCompile(`^(\d+):(\d+): (.*)$`)
 
-	defer f.Close()
-	raw, err := io.ReadAll(f)
-	if err != nil {
-		return nil, err
-	}
-	matches := importRegex.FindAllSubmatch(raw, -1)
-	result := &strings.Builder{}
-	result.Write(raw)
-	for idx := range matches {
-		p := string(matches[idx][1])
-		p = strings.TrimSpace(p)
-		f, err := l.content.Open(p)
-		if err != nil {
-			return nil, fmt.Errorf("loading shader %q failed, cannot open partial %q Error: %w", name, p, err)
+func newCompileError(src *Source, err error) error {
+	code := strings.Split(string(src.Code), "\n")
+	files := map[string][]string{}
+	issues := []Issue{}
+	for _, msg := range strings.Split(err.Error(), "\n") {
+		m := positionRegex.FindStringSubmatch(msg)
+		if m == nil {
+			issues = append(issues, Issue{Message: msg})
+			continue
 		}
-		raw, err := io.ReadAll(f)
-		if err != nil {
-			return nil, fmt.Errorf("loading shader %q failed, cannot read partial %q Error: %w", name, p, err)
+		line, _ := strconv.Atoi(m[1])
+		col, _ := strconv.Atoi(m[2])
+		issue := Issue{Column: col, Message: m[3]}
+		if o, ok := src.Origin(line); ok {
+			issue.Origin = o
+			if line <= len(code) {
+				issue.Code = code[line-1]
+			}
+			if _, ok := files[o.File]; !ok {
+				raw, _ := src.read(o.File)
+				files[o.File] = strings.Split(string(raw), "\n")
+			}
+			if lines := files[o.File]; o.Line > 0 && o.Line <= len(lines) {
+				issue.Source = strings.TrimRight(lines[o.Line-1], "\r")
+			}
 		}
-		f.Close()
-		result.WriteString("\n// ============\n")
-		result.WriteString("// Import: " + p)
-		result.WriteString("\n// ============\n")
-		result.Write(raw)
-		result.WriteString("\n")
-	}
-	shaderStr := result.String()
-	s, err := ebiten.NewShader([]byte(shaderStr))
-	if err != nil {
-		return nil, wrapCompileError(name, shaderStr, err)
-	}
-	l.cache[name] = &shader{
-		mod:    mod,
-		shader: s,
-	}
-	return s, nil
-}
-
-func wrapCompileError(shaderName string, raw string, err error) error {
-	s := bufio.NewScanner(strings.NewReader(raw))
-	line := 0
-	b := &strings.Builder{}
-	for s.Scan() {
-		line++
-		b.WriteString(fmt.Sprintf("%d %s\n", line, s.Text()))
+		issues = append(issues, issue)
 	}
 	return &CompileError{
-		shaderName: shaderName,
-		code:       b.String(),
-		err:        err,
+		Shader: src.Name,
+		Issues: issues,
+		err:    err,
 	}
 }
 
+// CompileError is returned when a shader does not compile, positions refer
+// to the original files.
 type CompileError struct {
-	err        error
-	shaderName string
-	code       string
+	Shader string
+	Issues []Issue
+	err    error
+}
+
+// Issue is a single compiler message. Source is the line in the original
+// file, Code the line after preprocessing.
+type Issue struct {
+	Origin
+	Column  int
+	Message string
+	Source  string
+	Code    string
+}
+
+func (i Issue) String() string {
+	if i.File == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", i.File, i.Line, i.Column, i.Message)
 }
 
 func (err *CompileError) Error() string {
-	return fmt.Sprintf("compile failed, shader %q. \n%s\nError: %s", err.shaderName, err.code, err.err)
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "compile failed, shader %q.", err.Shader)
+	for _, i := range err.Issues {
+		b.WriteString("\n" + i.String())
+		if i.Source != "" {
+			fmt.Fprintf(b, "\n%6d | %s", i.Line, i.Source)
+		}
+	}
+	return b.String()
+}
+
+func (err *CompileError) Unwrap() error {
+	return err.err
 }
@@ -1,13 +1,25 @@
 package kage
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
-func TestInit(t *testing.T) {
-	// nothing to do
-	if len(shaders) == 0 {
-		t.Error("no shaders loaded")
+func TestEmbedded(t *testing.T) {
+	list, err := content.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
 	}
-	for k, _ := range shaders {
-		t.Log(k)
+	if len(list) == 0 {
+		t.Error("no shaders embedded")
+	}
+	for _, f := range list {
+		if f.IsDir() {
+			continue
+		}
+		name := strings.TrimSuffix(f.Name(), ".kage")
+		if _, err := Embedded(name); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
 	}
 }
@@ -0,0 +1,244 @@
+package kage
+
+import (
+	"crypto/sha256"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NewLoader creates a loader with an empty cache.
+func NewLoader() *Loader {
+	return &Loader{
+		cache: map[[sha256.Size]byte]*cached{},
+	}
+}
+
+// Loader preprocesses and compiles Kage shaders. Compiled shaders are cached
+// by the hash of the preprocessed code, loading the same code again returns
+// the same shader without compiling it. Every load takes a reference that
+// Release gives back. A Loader is safe for concurrent use, Defines must not
+// be changed while shaders load.
+type Loader struct {
+	Defines map[string]string
+	mu      sync.Mutex
+	cache   map[[sha256.Size]byte]*cached
+}
+
+type cached struct {
+	shader *ebiten.Shader
+	refs   int
+}
+
+// Load compiles r with defines on top of l.Defines. Partials are imported
+// relative to the directory of r. Compile errors are returned as
+// *CompileError. On errors the source is returned too when its files are
+// known, see Preprocessor.Process.
+func (l *Loader) Load(r res.Resource, defines map[string]string) (*ebiten.Shader, *Source, error) {
+	src, err := l.preprocessor(r).Process(resourceName(r), defines)
+	if err != nil {
+		return nil, src, err
+	}
+	s, err := l.Compile(src)
+	if err != nil {
+		return nil, src, err
+	}
+	return s, src, nil
+}
+
+// LoadFS compiles name from fsys, see Load.
+func (l *Loader) LoadFS(fsys fs.FS, name string, defines map[string]string) (*ebiten.Shader, *Source, error) {
+	return l.Load(res.FromFS(fsys, name), defines)
+}
+
+// Compile compiles preprocessed code.
+func (l *Loader) Compile(src *Source) (*ebiten.Shader, error) {
+	hash := sha256.Sum256(src.Code)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.cache[hash]; ok {
+		c.refs++
+		return c.shader, nil
+	}
+	s, err := ebiten.NewShader(src.Code)
+	if err != nil {
+		return nil, newCompileError(src, err)
+	}
+	l.cache[hash] = &cached{shader: s, refs: 1}
+	return s, nil
+}
+
+// Release gives back a reference to s taken by Load or Compile. The last
+// release removes s from the cache and deallocates it.
+func (l *Loader) Release(s *ebiten.Shader) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for hash, c := range l.cache {
+		if c.shader != s {
+			continue
+		}
+		c.refs--
+		if c.refs <= 0 {
+			delete(l.cache, hash)
+			s.Deallocate()
+		}
+		return
+	}
+}
+
+// Len returns the number of cached shaders.
+func (l *Loader) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.cache)
+}
+
+// Clear drops all cached shaders, shaders in use are not disposed.
+func (l *Loader) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	clear(l.cache)
+}
+
+func (l *Loader) preprocessor(r res.Resource) *Preprocessor {
+	dir := res.Dir(r)
+	return &Preprocessor{
+		Defines: l.Defines,
+		Read: func(name string) ([]byte, error) {
+			return res.ReadAll(res.Join(dir, name))
+		},
+	}
+}
+
+func resourceName(r res.Resource) string {
+	return path.Base(filepath.ToSlash(r.String()))
+}
+
+// Watch loads r and recompiles it when r or one of its partials changes.
+// When the first load fails the Live is returned with the error, it keeps
+// watching the files that were read and has a shader once a reload
+// succeeds.
+func (l *Loader) Watch(r res.Resource, defines map[string]string) (*Live, error) {
+	live := &Live{
+		loader:   l,
+		r:        r,
+		defines:  defines,
+		interval: time.Second,
+	}
+	if err := live.Reload(); err != nil {
+		return live, err
+	}
+	return live, nil
+}
+
+// Live is a shader that is recompiled when its files change while Update is
+// called. A failed reload keeps the last valid shader, so a typo while
+// editing does not break the running game. A replaced shader is released
+// to the loader after the listeners switched to the new one. Unlike the Loader it is not safe
+// for concurrent use, it belongs to the game loop:
+//
+//	live, err := loader.Watch(res.MustParse("assets/water.kage"), nil)
+//	live.OnError = func(err error) { log.Println(err) }
+//	mat, err := shader.NewLiveMaterial(live)
+type Live struct {
+	loader    *Loader
+	r         res.Resource
+	defines   map[string]string
+	shader    *ebiten.Shader
+	source    *Source
+	err       error
+	interval  time.Duration
+	watchers  []*res.Watcher
+	changed   bool
+	listeners []func(s *ebiten.Shader, src *Source)
+	OnReload  func(s *ebiten.Shader, src *Source)
+	OnError   func(err error)
+}
+
+func (l *Live) Value() *ebiten.Shader {
+	return l.shader
+}
+
+// Source returns the preprocessed code of the current shader.
+func (l *Live) Source() *Source {
+	return l.source
+}
+
+// Err returns the error of the last reload.
+func (l *Live) Err() error {
+	return l.err
+}
+
+func (l *Live) SetInterval(interval time.Duration) {
+	l.interval = interval
+	for _, w := range l.watchers {
+		w.Interval = interval
+	}
+}
+
+// Subscribe calls fn with every new shader, e.g. to swap it into materials.
+func (l *Live) Subscribe(fn func(s *ebiten.Shader, src *Source)) {
+	l.listeners = append(l.listeners, fn)
+}
+
+// Reload compiles the shader again. Listeners are only called when the code
+// changed.
+func (l *Live) Reload() error {
+	s, src, err := l.loader.Load(l.r, l.defines)
+	l.err = err
+	if err != nil {
+		if l.OnError != nil {
+			l.OnError(err)
+		}
+		if src != nil {
+			l.watch(src)
+		}
+		return err
+	}
+	l.watch(src)
+	if s == l.shader {
+		l.loader.Release(s)
+		return nil
+	}
+	old := l.shader
+	l.shader = s
+	l.source = src
+	for _, fn := range l.listeners {
+		fn(s, src)
+	}
+	if l.OnReload != nil {
+		l.OnReload(s, src)
+	}
+	if old != nil {
+		l.loader.Release(old)
+	}
+	return nil
+}
+
+// watch polls the files the source was built from, imports may have
+// changed with the last edit.
+func (l *Live) watch(src *Source) {
+	dir := res.Dir(l.r)
+	l.watchers = l.watchers[:0]
+	for _, f := range src.Files {
+		l.watchers = append(l.watchers, res.NewWatcher(res.Join(dir, f), l.interval, func() {
+			l.changed = true
+		}))
+	}
+}
+
+func (l *Live) Update(dt time.Duration) {
+	for _, w := range l.watchers {
+		w.Update(dt)
+	}
+	if l.changed {
+		l.changed = false
+		l.Reload()
+	}
+}
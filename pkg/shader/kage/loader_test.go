@@ -0,0 +1,176 @@
+package kage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	testMain = `//kage:unit pixels
+//import:partial partials/tint.kage
+
+package main
+
+func Fragment(_ vec4, _ vec2, _ vec4) vec4 {
+	return tint()
+}
+`
+	testTint   = "func tint() vec4 {\n\treturn vec4(1)\n}\n"
+	testBroken = "func tint() vec4 {\n\treturn foo\n}\n"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string, mod time.Time) {
+	t.Helper()
+	for name, data := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoaderConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.kage":             testMain,
+		"partials/tint.kage": testTint,
+	}, time.Now())
+
+	l := NewLoader()
+	shaders := make([]*ebiten.Shader, 8)
+	var wg sync.WaitGroup
+	for idx := range shaders {
+		wg.Go(func() {
+			s, _, err := l.LoadFS(os.DirFS(dir), "a.kage", nil)
+			if err != nil {
+				t.Error(err)
+			}
+			shaders[idx] = s
+		})
+	}
+	wg.Wait()
+	for _, s := range shaders[1:] {
+		if s != shaders[0] {
+			t.Fatal("concurrent loads compiled the shader more than once")
+		}
+	}
+	if l.Len() != 1 {
+		t.Errorf("expected 1 cached shader but got %d", l.Len())
+	}
+}
+
+func TestLoaderCache(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.kage":             testMain,
+		"b.kage":             testMain,
+		"partials/tint.kage": testTint,
+	}, time.Now())
+
+	l := NewLoader()
+	a, _, err := l.LoadFS(os.DirFS(dir), "a.kage", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := l.Load(res.MustParse(filepath.Join(dir, "b.kage")), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b || l.Len() != 1 {
+		t.Errorf("same code compiled twice, cached %d", l.Len())
+	}
+	l.Release(a)
+	if l.Len() != 1 {
+		t.Errorf("expected the shader to stay cached while b holds it")
+	}
+	l.Release(b)
+	if l.Len() != 0 {
+		t.Errorf("expected the last release to evict the shader but %d cached", l.Len())
+	}
+}
+
+func TestLoaderCompileError(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.kage":             testMain,
+		"partials/tint.kage": testBroken,
+	}, time.Now())
+
+	_, _, err := NewLoader().LoadFS(os.DirFS(dir), "a.kage", nil)
+	var cerr *CompileError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("err = %v, want *CompileError", err)
+	}
+	if len(cerr.Issues) == 0 || cerr.Issues[0].File != "partials/tint.kage" || cerr.Issues[0].Line != 2 {
+		t.Errorf("issues = %+v", cerr.Issues)
+	}
+}
+
+func TestLive(t *testing.T) {
+	dir := t.TempDir()
+	mod := time.Now().Add(-time.Hour)
+	writeFiles(t, dir, map[string]string{
+		"a.kage":             testMain,
+		"partials/tint.kage": testTint,
+	}, mod)
+
+	loader := NewLoader()
+	live, err := loader.Watch(res.MustParse(filepath.Join(dir, "a.kage")), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live.SetInterval(0)
+	first := live.Value()
+	swapped := 0
+	live.Subscribe(func(s *ebiten.Shader, src *Source) { swapped++ })
+
+	// a broken partial keeps the last shader
+	writeFiles(t, dir, map[string]string{"partials/tint.kage": testBroken}, mod.Add(time.Minute))
+	live.Update(time.Millisecond)
+	if live.Err() == nil || live.Value() != first || swapped != 0 {
+		t.Fatalf("err = %v, swapped = %d", live.Err(), swapped)
+	}
+
+	writeFiles(t, dir, map[string]string{"partials/tint.kage": "func tint() vec4 {\n\treturn vec4(0.5)\n}\n"}, mod.Add(2*time.Minute))
+	live.Update(time.Millisecond)
+	if live.Err() != nil || live.Value() == first || swapped != 1 {
+		t.Errorf("err = %v, swapped = %d", live.Err(), swapped)
+	}
+	if loader.Len() != 1 {
+		t.Errorf("expected the replaced shader to be evicted but %d cached", loader.Len())
+	}
+}
+
+func TestLiveFailedFirstLoad(t *testing.T) {
+	dir := t.TempDir()
+	mod := time.Now().Add(-time.Hour)
+	writeFiles(t, dir, map[string]string{
+		"a.kage":             testMain,
+		"partials/tint.kage": testBroken,
+	}, mod)
+
+	live, err := NewLoader().Watch(res.MustParse(filepath.Join(dir, "a.kage")), nil)
+	if err == nil || live == nil || live.Value() != nil {
+		t.Fatalf("expected a live without shader and an error but got %v", err)
+	}
+	live.SetInterval(0)
+	writeFiles(t, dir, map[string]string{"partials/tint.kage": testTint}, mod.Add(time.Minute))
+	live.Update(time.Millisecond)
+	if live.Err() != nil || live.Value() == nil {
+		t.Errorf("expected the fixed partial to compile but got %v", live.Err())
+	}
+}
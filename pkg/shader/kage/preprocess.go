@@ -0,0 +1,323 @@
+package kage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Preprocessor resolves the directives of a kage file before it is compiled.
+// Directives are line comments, so the files stay valid Kage:
+//
+//	//import:partial partials/color.kage   appends a partial once, partials may import others
+//	//define:SAMPLES 10                    replaces the identifier SAMPLES in the following code
+//	//define:HQ                            defines a flag without value
+//	//ifdef:HQ, //ifndef:HQ, //else, //endif
+//	//variant:fast SAMPLES=4 HQ            declares a named set of defines
+//
+// Defines passed to Process win over the ones in the files. Import paths are
+// relative to the root of the file system.
+type Preprocessor struct {
+	Read    func(name string) ([]byte, error)
+	Defines map[string]string
+}
+
+// NewPreprocessor reads the files from fsys.
+func NewPreprocessor(fsys fs.FS) *Preprocessor {
+	return &Preprocessor{
+		Read: func(name string) ([]byte, error) {
+			return fs.ReadFile(fsys, name)
+		},
+	}
+}
+
+// Source is a preprocessed shader.
+type Source struct {
+	Name     string
+	Code     []byte
+	Files    []string
+	Variants []Variant
+	lines    []Origin
+	read     func(name string) ([]byte, error)
+}
+
+// Variant is a named set of defines declared with //variant.
+type Variant struct {
+	Name    string
+	Defines map[string]string
+}
+
+// Origin is a line in one of the files a source was built from.
+type Origin struct {
+	File string
+	Line int
+}
+
+func (o Origin) String() string {
+	return fmt.Sprintf("%s:%d", o.File, o.Line)
+}
+
+// Origin returns where line (1 based) of the processed code came from.
+func (s *Source) Origin(line int) (Origin, bool) {
+	if line < 1 || line > len(s.lines) {
+		return Origin{}, false
+	}
+	return s.lines[line-1], true
+}
+
+// Variant returns the declared variant called name.
+func (s *Source) Variant(name string) (Variant, bool) {
+	for _, v := range s.Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+var identRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Process reads name and all imported partials. defines are applied on top
+// of p.Defines. On errors the returned source has no code, its Files list
+// the files read so far and the one that could not be read, so they can be
+// watched for a fix.
+func (p *Preprocessor) Process(name string, defines map[string]string) (*Source, error) {
+	st := &state{
+		p:       p,
+		src:     &Source{Name: name, read: p.Read},
+		fixed:   map[string]bool{},
+		defines: map[string]string{},
+		seen:    map[string]bool{},
+	}
+	for k, v := range p.Defines {
+		st.defines[k] = v
+		st.fixed[k] = true
+	}
+	for k, v := range defines {
+		st.defines[k] = v
+		st.fixed[k] = true
+	}
+	name = path.Clean(name)
+	if err := st.process(name, true); err != nil {
+		return st.src, err
+	}
+	st.src.Code = st.out.Bytes()
+	return st.src, nil
+}
+
+// ProcessVariant processes name with the defines of its declared variant.
+func (p *Preprocessor) ProcessVariant(name, variant string) (*Source, error) {
+	base, err := p.Process(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := base.Variant(variant)
+	if !ok {
+		return nil, fmt.Errorf("shader %q has no variant %q", name, variant)
+	}
+	return p.Process(name, v.Defines)
+}
+
+type state struct {
+	p       *Preprocessor
+	src     *Source
+	out     bytes.Buffer
+	defines map[string]string
+	fixed   map[string]bool
+	seen    map[string]bool
+	stack   []string
+}
+
+type cond struct {
+	active, parent, sawElse bool
+	at                      Origin
+}
+
+func (st *state) emit(line string, o Origin) {
+	st.out.WriteString(line)
+	st.out.WriteByte('\n')
+	st.src.lines = append(st.src.lines, o)
+}
+
+func (st *state) process(name string, main bool) error {
+	st.src.Files = append(st.src.Files, name)
+	raw, err := st.p.Read(name)
+	if err != nil {
+		if main {
+			return fmt.Errorf("loading shader %q failed: %w", name, err)
+		}
+		return fmt.Errorf("loading shader %q failed, cannot read partial %q: %w", st.src.Name, name, err)
+	}
+	st.seen[name] = true
+	st.stack = append(st.stack, name)
+	defer func() { st.stack = st.stack[:len(st.stack)-1] }()
+
+	var imports []string
+	var importAt []Origin
+	var conds []cond
+	active := true
+	s := bufio.NewScanner(bytes.NewReader(raw))
+	line := 0
+	for s.Scan() {
+		line++
+		text := strings.TrimRight(s.Text(), "\r")
+		at := Origin{name, line}
+		directive, arg, isDirective := parseDirective(text)
+		if isDirective {
+			switch directive {
+			case "ifdef", "ifndef":
+				_, ok := st.defines[arg]
+				if directive == "ifndef" {
+					ok = !ok
+				}
+				conds = append(conds, cond{active: active && ok, parent: active, at: at})
+				active = active && ok
+			case "else":
+				if len(conds) == 0 {
+					return fmt.Errorf("%s: //else without //ifdef", at)
+				}
+				c := &conds[len(conds)-1]
+				if c.sawElse {
+					return fmt.Errorf("%s: second //else for //ifdef at %s", at, c.at)
+				}
+				c.sawElse = true
+				c.active = c.parent && !c.active
+				active = c.active
+			case "endif":
+				if len(conds) == 0 {
+					return fmt.Errorf("%s: //endif without //ifdef", at)
+				}
+				active = conds[len(conds)-1].parent
+				conds = conds[:len(conds)-1]
+			}
+			if !active {
+				continue
+			}
+			switch directive {
+			case "import:partial":
+				if arg == "" {
+					return fmt.Errorf("%s: //import:partial without path", at)
+				}
+				imports = append(imports, path.Clean(arg))
+				importAt = append(importAt, at)
+			case "define":
+				key, val, _ := strings.Cut(arg, " ")
+				if !identRegex.MatchString(key) {
+					return fmt.Errorf("%s: invalid define %q", at, key)
+				}
+				if !st.fixed[key] {
+					st.defines[key] = strings.TrimSpace(val)
+				}
+			case "variant":
+				if !main {
+					return fmt.Errorf("%s: //variant is only allowed in the shader, not in partials", at)
+				}
+				v, err := parseVariant(arg)
+				if err != nil {
+					return fmt.Errorf("%s: %w", at, err)
+				}
+				st.src.Variants = append(st.src.Variants, v)
+			}
+			st.emit(text, at)
+			continue
+		}
+		if !active {
+			continue
+		}
+		if !main && isFileHeader(text) {
+			continue
+		}
+		st.emit(st.substitute(text), at)
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("reading shader %q failed: %w", name, err)
+	}
+	if len(conds) > 0 {
+		return fmt.Errorf("%s: //ifdef without //endif", conds[len(conds)-1].at)
+	}
+
+	for idx, imp := range imports {
+		if i := slices.Index(st.stack, imp); i >= 0 {
+			cycle := append(slices.Clone(st.stack[i:]), imp)
+			return fmt.Errorf("%s: import cycle %s", importAt[idx], strings.Join(cycle, " -> "))
+		}
+		if st.seen[imp] {
+			continue
+		}
+		st.emit("// ============", Origin{imp, 0})
+		st.emit("// Import: "+imp, Origin{imp, 0})
+		st.emit("// ============", Origin{imp, 0})
+		if err := st.process(imp, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseDirective(text string) (string, string, bool) {
+	trimmed := strings.TrimSpace(text)
+	rest, ok := strings.CutPrefix(trimmed, "//")
+	if !ok {
+		return "", "", false
+	}
+	switch rest {
+	case "else", "endif":
+		return rest, "", true
+	}
+	for _, d := range []string{"import:partial", "define", "ifdef", "ifndef", "variant"} {
+		if arg, ok := strings.CutPrefix(rest, d); ok {
+			if d == "import:partial" {
+				return d, strings.TrimSpace(arg), true
+			}
+			if arg, ok := strings.CutPrefix(arg, ":"); ok {
+				return d, strings.TrimSpace(arg), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func parseVariant(arg string) (Variant, error) {
+	fields := strings.Fields(arg)
+	if len(fields) == 0 || !identRegex.MatchString(fields[0]) {
+		return Variant{}, fmt.Errorf("invalid variant %q", arg)
+	}
+	v := Variant{Name: fields[0], Defines: map[string]string{}}
+	for _, f := range fields[1:] {
+		key, val, _ := strings.Cut(f, "=")
+		if !identRegex.MatchString(key) {
+			return Variant{}, fmt.Errorf("invalid define %q in variant %q", f, v.Name)
+		}
+		v.Defines[key] = val
+	}
+	return v, nil
+}
+
+func isFileHeader(text string) bool {
+	t := strings.TrimSpace(text)
+	return strings.HasPrefix(t, "//go:build") ||
+		strings.HasPrefix(t, "//kage:unit") ||
+		strings.HasPrefix(t, "package ")
+}
+
+var wordRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// substitute replaces defines with a value in the code part of a line.
+func (st *state) substitute(text string) string {
+	code, comment := text, ""
+	if idx := strings.Index(text, "//"); idx >= 0 {
+		code, comment = text[:idx], text[idx:]
+	}
+	code = wordRegex.ReplaceAllStringFunc(code, func(word string) string {
+		if v, ok := st.defines[word]; ok && v != "" {
+			return v
+		}
+		return word
+	})
+	return code + comment
+}
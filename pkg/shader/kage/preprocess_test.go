@@ -0,0 +1,243 @@
+package kage
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func file(lines ...string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(strings.Join(lines, "\n") + "\n")}
+}
+
+var testFS = fstest.MapFS{
+	"main.kage": file(
+		"//kage:unit pixels",
+		"//import:partial partials/a.kage",
+		"//import:partial partials/b.kage",
+		"package main",
+		"func Fragment(_ vec4, _ vec2, _ vec4) vec4 {",
+		"\treturn vec4(a() + b())",
+		"}",
+	),
+	"partials/a.kage": file(
+		"//go:build ignore",
+		"//import:partial partials/c.kage",
+		"package main",
+		"func a() float { return c() }",
+	),
+	"partials/b.kage": file(
+		"//import:partial partials/c.kage",
+		"func b() float { return c() }",
+	),
+	"partials/c.kage": file(
+		"func c() float { return 1 }",
+	),
+	"cycle.kage": file(
+		"//import:partial partials/x.kage",
+		"package main",
+	),
+	"partials/x.kage": file("//import:partial partials/y.kage"),
+	"partials/y.kage": file("//import:partial partials/x.kage"),
+	"defines.kage": file(
+		"//define:SAMPLES 10",
+		"//variant:fast SAMPLES=4",
+		"//variant:hq HQ",
+		"package main",
+		"var n = SAMPLES // SAMPLES",
+		"//ifdef:HQ",
+		"var hq = true",
+		"//else",
+		"var hq = false",
+		"//endif",
+		"//ifndef:HQ",
+		"var low = SAMPLES_MAX",
+		"//endif",
+	),
+	"unbalanced.kage": file(
+		"//ifdef:HQ",
+		"package main",
+	),
+}
+
+func TestNestedImports(t *testing.T) {
+	src, err := NewPreprocessor(testFS).Process("main.kage", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"main.kage", "partials/a.kage", "partials/c.kage", "partials/b.kage"}
+	if strings.Join(src.Files, ",") != strings.Join(want, ",") {
+		t.Errorf("files = %v, want %v", src.Files, want)
+	}
+	code := string(src.Code)
+	if n := strings.Count(code, "func c()"); n != 1 {
+		t.Errorf("c imported %d times, want once:\n%s", n, code)
+	}
+	if n := strings.Count(code, "package main"); n != 1 {
+		t.Errorf("%d package clauses, want 1:\n%s", n, code)
+	}
+	if strings.Contains(code, "//go:build") {
+		t.Errorf("build tag of partial not stripped:\n%s", code)
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	src, err := NewPreprocessor(testFS).Process("main.kage", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, line := range strings.Split(string(src.Code), "\n") {
+		var want Origin
+		switch line {
+		case "func a() float { return c() }":
+			want = Origin{"partials/a.kage", 4}
+		case "func c() float { return 1 }":
+			want = Origin{"partials/c.kage", 1}
+		case "\treturn vec4(a() + b())":
+			want = Origin{"main.kage", 6}
+		default:
+			continue
+		}
+		if got, _ := src.Origin(idx + 1); got != want {
+			t.Errorf("origin of %q = %v, want %v", line, got, want)
+		}
+	}
+	if _, ok := src.Origin(0); ok {
+		t.Error("line 0 has an origin")
+	}
+}
+
+func TestImportCycle(t *testing.T) {
+	_, err := NewPreprocessor(testFS).Process("cycle.kage", nil)
+	if err == nil || !strings.Contains(err.Error(), "partials/x.kage -> partials/y.kage -> partials/x.kage") {
+		t.Errorf("err = %v, want import cycle", err)
+	}
+}
+
+func TestMissingPartial(t *testing.T) {
+	fsys := fstest.MapFS{"a.kage": file("//import:partial nope.kage")}
+	src, err := NewPreprocessor(fsys).Process("a.kage", nil)
+	if err == nil || !strings.Contains(err.Error(), `"nope.kage"`) {
+		t.Errorf("err = %v, want missing partial", err)
+	}
+	if src == nil {
+		t.Fatal("no source for the failed import")
+	}
+	if want := []string{"a.kage", "nope.kage"}; !slices.Equal(src.Files, want) {
+		t.Errorf("files = %q, want %q", src.Files, want)
+	}
+}
+
+func TestDefines(t *testing.T) {
+	p := NewPreprocessor(testFS)
+	tests := []struct {
+		name    string
+		src     func() (*Source, error)
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "default",
+			src:     func() (*Source, error) { return p.Process("defines.kage", nil) },
+			want:    []string{"var n = 10 // SAMPLES", "var hq = false", "var low = SAMPLES_MAX"},
+			notWant: []string{"var hq = true"},
+		},
+		{
+			name: "override",
+			src: func() (*Source, error) {
+				return p.Process("defines.kage", map[string]string{"SAMPLES": "32"})
+			},
+			want: []string{"var n = 32"},
+		},
+		{
+			name:    "variant fast",
+			src:     func() (*Source, error) { return p.ProcessVariant("defines.kage", "fast") },
+			want:    []string{"var n = 4", "var hq = false"},
+			notWant: []string{"var hq = true"},
+		},
+		{
+			name:    "variant hq",
+			src:     func() (*Source, error) { return p.ProcessVariant("defines.kage", "hq") },
+			want:    []string{"var n = 10", "var hq = true"},
+			notWant: []string{"var hq = false", "var low"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := tt.src()
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := string(src.Code)
+			for _, w := range tt.want {
+				if !strings.Contains(code, w) {
+					t.Errorf("missing %q in:\n%s", w, code)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(code, w) {
+					t.Errorf("unexpected %q in:\n%s", w, code)
+				}
+			}
+		})
+	}
+	src, err := p.Process("defines.kage", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(src.Variants) != 2 || src.Variants[0].Name != "fast" || src.Variants[0].Defines["SAMPLES"] != "4" {
+		t.Errorf("variants = %v", src.Variants)
+	}
+	if _, err := p.ProcessVariant("defines.kage", "nope"); err == nil {
+		t.Error("unknown variant did not fail")
+	}
+}
+
+func TestUnbalanced(t *testing.T) {
+	_, err := NewPreprocessor(testFS).Process("unbalanced.kage", nil)
+	if err == nil || !strings.Contains(err.Error(), "unbalanced.kage:1") {
+		t.Errorf("err = %v, want unbalanced //ifdef", err)
+	}
+}
+
+func TestCompileErrorOrigin(t *testing.T) {
+	src, err := NewPreprocessor(testFS).Process("main.kage", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := 0
+	for idx, l := range strings.Split(string(src.Code), "\n") {
+		if strings.HasPrefix(l, "func c()") {
+			line = idx + 1
+		}
+	}
+	cause := errors.New(strings.Join([]string{
+		strconv.Itoa(line) + ":25: unexpected identifier: foo",
+		"shader: something without position",
+	}, "\n"))
+	err = newCompileError(src, cause)
+
+	var cerr *CompileError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("err = %T, want *CompileError", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("cause not wrapped")
+	}
+	if len(cerr.Issues) != 2 {
+		t.Fatalf("issues = %v", cerr.Issues)
+	}
+	i := cerr.Issues[0]
+	if i.File != "partials/c.kage" || i.Line != 1 || i.Column != 25 || i.Source != "func c() float { return 1 }" {
+		t.Errorf("issue = %+v", i)
+	}
+	if !strings.Contains(err.Error(), "partials/c.kage:1:25: unexpected identifier: foo") {
+		t.Errorf("message = %s", err)
+	}
+	if cerr.Issues[1].File != "" || cerr.Issues[1].Message != "shader: something without position" {
+		t.Errorf("issue = %+v", cerr.Issues[1])
+	}
+}
@@ -1,23 +1,22 @@
 package shader
 
 import (
-	_ "embed"
 	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
-)
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
 
-var (
-	//go:embed kage/light2.kage
-	lightRaw []byte
-	light    = mustNewShader(lightRaw)
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
-func NewLight(offset float32) *LightShader {
+func NewLight(offset float32) (*LightShader, error) {
+	s, err := kage.Embedded("light2")
+	if err != nil {
+		return nil, err
+	}
 	return &LightShader{
-		shader: light,
+		shader: s,
 		Offset: offset,
-	}
+	}, nil
 }
 
 type LightShader struct {
@@ -27,13 +26,14 @@ type LightShader struct {
 	Offset float32
 }
 
-func (s *LightShader) Update(dt time.Duration) {
-	x, y := ebiten.CursorPosition()
-	s.PosX = float32(x) + s.Offset
-	s.PosY = float32(y) + s.Offset
-
+// SetPosition moves the light to x, y in pixels of the source image.
+func (s *LightShader) SetPosition(x, y float32) {
+	s.PosX = x + s.Offset
+	s.PosY = y + s.Offset
 }
 
+func (s *LightShader) Update(dt time.Duration) {}
+
 func (s *LightShader) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
 	w, h := srcImage.Bounds().Dx(), srcImage.Bounds().Dy()
 	op.Uniforms = map[string]any{
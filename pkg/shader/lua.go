@@ -0,0 +1,70 @@
+package shader
+
+import (
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+	vm "github.com/weakpixel/ebitenkiso/pkg/vm/lua"
+
+	"github.com/Shopify/go-lua"
+)
+
+// Materials names materials for scripts.
+type Materials map[string]*Material
+
+// Register exposes the materials to a script environment:
+//
+//	setUniform(material, uniform, values...)
+//	getUniform(material, uniform) -- returns all components
+//	tweenUniform(material, uniform, to, seconds, easing)
+func (m Materials) Register(env *vm.Env) {
+	env.RegisterFn("setUniform", func(l *lua.State) int {
+		mat := m.get(l)
+		name := lua.CheckString(l, 2)
+		values := make([]float64, 0, l.Top()-2)
+		for idx := 3; idx <= l.Top(); idx++ {
+			values = append(values, lua.CheckNumber(l, idx))
+		}
+		if err := mat.Set(name, values...); err != nil {
+			lua.Errorf(l, "%s", err.Error())
+		}
+		return 0
+	})
+	env.RegisterFn("getUniform", func(l *lua.State) int {
+		values := m.get(l).Get(lua.CheckString(l, 2))
+		for _, v := range values {
+			l.PushNumber(v)
+		}
+		return len(values)
+	})
+	env.RegisterFn("tweenUniform", func(l *lua.State) int {
+		mat := m.get(l)
+		name := lua.CheckString(l, 2)
+		to := lua.CheckNumber(l, 3)
+		seconds := lua.CheckNumber(l, 4)
+		easing := tween.Linear
+		if n, ok := l.ToString(5); ok {
+			if easing, ok = tween.Easing(n); !ok {
+				lua.Errorf(l, "unknown easing %q", n)
+			}
+		}
+		p := mat.Float(name)
+		if p == nil {
+			lua.Errorf(l, "unknown uniform %q", name)
+		}
+		d := time.Duration(seconds * float64(time.Second))
+		if err := mat.BindTween(name, tween.New(*p, to, d, easing)); err != nil {
+			lua.Errorf(l, "%s", err.Error())
+		}
+		return 0
+	})
+}
+
+func (m Materials) get(l *lua.State) *Material {
+	name := lua.CheckString(l, 1)
+	mat, ok := m[name]
+	if !ok {
+		lua.Errorf(l, "unknown material %q", name)
+	}
+	return mat
+}
@@ -0,0 +1,318 @@
+package shader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NewMaterial compiles a Kage source and discovers its uniforms.
+func NewMaterial(src []byte) (*Material, error) {
+	uniforms, err := ParseUniforms(src)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ebiten.NewShader(src)
+	if err != nil {
+		return nil, fmt.Errorf("ebiten.NewShader failed: %w", err)
+	}
+	return NewMaterialFor(s, uniforms), nil
+}
+
+// NewMaterialFor wraps a compiled shader with the given uniforms.
+func NewMaterialFor(s *ebiten.Shader, uniforms []Uniform) *Material {
+	m := &Material{
+		values: map[string][]float64{},
+	}
+	m.SetShader(s, uniforms)
+	return m
+}
+
+// NewLiveMaterial creates a material for a watched shader, every recompiled
+// version is swapped in, see Follow.
+func NewLiveMaterial(l *kage.Live) (*Material, error) {
+	uniforms, err := ParseUniforms(l.Source().Code)
+	if err != nil {
+		return nil, err
+	}
+	m := NewMaterialFor(l.Value(), uniforms)
+	m.Follow(l)
+	return m, nil
+}
+
+// Material is a Shader for any Kage source. Uniforms are set by name, bound
+// to tweens or functions, or addressed by pointer:
+//
+//	tween.Bind(tween.New(0, 1, time.Second, tween.OutQuad), mat.Float("Intensity"))
+//	mat.Bind("Fill", cooldown.Progress)
+//	env.RegisterGetterSetterNumber("glow", mat.Float("Intensity"))
+type Material struct {
+	// Images are passed to the shader as images 1 to 3.
+	Images   [3]*ebiten.Image
+	shader   *ebiten.Shader
+	uniforms []Uniform
+	values   map[string][]float64
+	bindings []binding
+}
+
+type binding struct {
+	target *float64
+	fn     func() float64
+	tween  tween.Updater
+}
+
+// Follow swaps the shader of l into m whenever it is recompiled. Uniform
+// values and bindings are kept as long as the uniform still exists.
+func (m *Material) Follow(l *kage.Live) {
+	l.Subscribe(func(s *ebiten.Shader, src *kage.Source) {
+		uniforms, err := ParseUniforms(src.Code)
+		if err != nil {
+			if l.OnError != nil {
+				l.OnError(err)
+			}
+			return
+		}
+		m.SetShader(s, uniforms)
+	})
+}
+
+// SetShader replaces the shader, values of uniforms with the same name and
+// type are kept.
+func (m *Material) SetShader(s *ebiten.Shader, uniforms []Uniform) {
+	values := make(map[string][]float64, len(uniforms))
+	for _, u := range uniforms {
+		old, ok := m.values[u.Name]
+		if ok && len(old) == u.Count() {
+			values[u.Name] = old
+			continue
+		}
+		values[u.Name] = make([]float64, u.Count())
+	}
+	m.shader = s
+	m.uniforms = uniforms
+	m.values = values
+
+	bindings := m.bindings[:0]
+	for _, b := range m.bindings {
+		if m.owns(b.target) {
+			bindings = append(bindings, b)
+		}
+	}
+	m.bindings = bindings
+}
+
+func (m *Material) owns(p *float64) bool {
+	for _, v := range m.values {
+		for idx := range v {
+			if &v[idx] == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *Material) Shader() *ebiten.Shader {
+	return m.shader
+}
+
+func (m *Material) Uniforms() []Uniform {
+	return m.uniforms
+}
+
+func (m *Material) Uniform(name string) (Uniform, bool) {
+	for _, u := range m.uniforms {
+		if u.Name == name {
+			return u, true
+		}
+	}
+	return Uniform{}, false
+}
+
+// Get returns all components of a uniform.
+func (m *Material) Get(name string) []float64 {
+	return m.values[name]
+}
+
+// Set sets the components of a uniform starting with the first.
+func (m *Material) Set(name string, v ...float64) error {
+	values, ok := m.values[name]
+	if !ok {
+		return fmt.Errorf("unknown uniform %q", name)
+	}
+	if len(v) > len(values) {
+		return fmt.Errorf("uniform %q has %d components but got %d", name, len(values), len(v))
+	}
+	copy(values, v)
+	return nil
+}
+
+func (m *Material) SetFloat(name string, v float64) error {
+	return m.set(name, 1, v)
+}
+
+func (m *Material) SetInt(name string, v int) error {
+	return m.set(name, 1, float64(v))
+}
+
+func (m *Material) SetVec2(name string, x, y float64) error {
+	return m.set(name, 2, x, y)
+}
+
+func (m *Material) SetVec3(name string, x, y, z float64) error {
+	return m.set(name, 3, x, y, z)
+}
+
+func (m *Material) SetVec4(name string, x, y, z, w float64) error {
+	return m.set(name, 4, x, y, z, w)
+}
+
+func (m *Material) set(name string, size int, v ...float64) error {
+	u, ok := m.Uniform(name)
+	if !ok {
+		return fmt.Errorf("unknown uniform %q", name)
+	}
+	if u.Size() != size {
+		return fmt.Errorf("uniform %q is a %s", name, u.Type)
+	}
+	copy(m.values[name], v)
+	return nil
+}
+
+// Float returns a pointer to a component of a uniform, nil if it does not
+// exist. name is the uniform name optionally followed by an array index
+// and a component: "Intensity", "Value.y", "Lights[2].x" or "Tint.a".
+func (m *Material) Float(name string) *float64 {
+	base, idx, comp := splitComponent(name)
+	u, ok := m.Uniform(base)
+	if !ok || idx >= max(u.Len, 1) || comp >= u.Size() {
+		return nil
+	}
+	return &m.values[base][idx*u.Size()+comp]
+}
+
+// Property implements the target of keyframe clips.
+func (m *Material) Property(name string) *float64 {
+	return m.Float(strings.TrimPrefix(name, "uniform."))
+}
+
+func splitComponent(name string) (string, int, int) {
+	comp := 0
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		c := strings.IndexAny("xyzwrgba", name[dot+1:])
+		if len(name[dot+1:]) != 1 || c < 0 {
+			return name, 0, 16
+		}
+		comp = c % 4
+		name = name[:dot]
+	}
+	idx := 0
+	if open := strings.IndexByte(name, '['); open >= 0 && strings.HasSuffix(name, "]") {
+		n, err := strconv.Atoi(name[open+1 : len(name)-1])
+		if err != nil || n < 0 {
+			return name, 0, 16
+		}
+		idx = n
+		name = name[:open]
+	}
+	return name, idx, comp
+}
+
+// Bind sets a uniform component from fn on every Update, e.g. from a
+// timer or cooldown progress.
+func (m *Material) Bind(name string, fn func() float64) error {
+	p := m.Float(name)
+	if p == nil {
+		return fmt.Errorf("unknown uniform %q", name)
+	}
+	m.Unbind(name)
+	m.bindings = append(m.bindings, binding{target: p, fn: fn})
+	return nil
+}
+
+// BindTween drives a uniform component with u until it is done.
+func (m *Material) BindTween(name string, u tween.Updater) error {
+	p := m.Float(name)
+	if p == nil {
+		return fmt.Errorf("unknown uniform %q", name)
+	}
+	m.Unbind(name)
+	m.bindings = append(m.bindings, binding{target: p, tween: u})
+	return nil
+}
+
+// Unbind removes the binding of a uniform component.
+func (m *Material) Unbind(name string) {
+	p := m.Float(name)
+	bindings := m.bindings[:0]
+	for _, b := range m.bindings {
+		if b.target != p {
+			bindings = append(bindings, b)
+		}
+	}
+	m.bindings = bindings
+}
+
+func (m *Material) Update(dt time.Duration) {
+	bindings := m.bindings[:0]
+	for _, b := range m.bindings {
+		if b.fn != nil {
+			*b.target = b.fn()
+			bindings = append(bindings, b)
+			continue
+		}
+		v, done := b.tween.Update(dt)
+		*b.target = v
+		if !done {
+			bindings = append(bindings, b)
+		}
+	}
+	clear(m.bindings[len(bindings):])
+	m.bindings = bindings
+}
+
+// UniformValues returns the uniforms in the form of
+// ebiten.DrawRectShaderOptions.Uniforms.
+func (m *Material) UniformValues() map[string]any {
+	uniforms := make(map[string]any, len(m.uniforms))
+	for _, u := range m.uniforms {
+		v := m.values[u.Name]
+		switch {
+		case u.Int() && u.Count() == 1:
+			uniforms[u.Name] = int32(v[0])
+		case u.Int():
+			ints := make([]int32, len(v))
+			for idx := range v {
+				ints[idx] = int32(v[idx])
+			}
+			uniforms[u.Name] = ints
+		case u.Count() == 1:
+			uniforms[u.Name] = float32(v[0])
+		default:
+			floats := make([]float32, len(v))
+			for idx := range v {
+				floats[idx] = float32(v[idx])
+			}
+			uniforms[u.Name] = floats
+		}
+	}
+	return uniforms
+}
+
+func (m *Material) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
+	w, h := srcImage.Bounds().Dx(), srcImage.Bounds().Dy()
+	op.Uniforms = m.UniformValues()
+	op.Images[0] = srcImage
+	for idx, img := range m.Images {
+		if img != nil {
+			op.Images[idx+1] = img
+		}
+	}
+	screen.DrawRectShader(w, h, m.shader, op)
+}
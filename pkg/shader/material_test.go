@@ -0,0 +1,86 @@
+package shader
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+const uniformSrc = `//kage:unit pixels
+package main
+
+// Time in seconds
+var Time float
+var (
+	Tint vec4 /* rgba */
+	Lights [4]vec3
+	Mode, Steps int
+	hidden float
+)
+
+func Fragment(position vec4, texCoord vec2, color vec4) vec4 {
+	var local float
+	return color
+}
+`
+
+func TestParseUniforms(t *testing.T) {
+	uniforms, err := ParseUniforms([]byte(uniformSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Uniform{
+		{Name: "Time", Type: "float"},
+		{Name: "Tint", Type: "vec4"},
+		{Name: "Lights", Type: "vec3", Len: 4},
+		{Name: "Mode", Type: "int"},
+		{Name: "Steps", Type: "int"},
+	}
+	if !slices.Equal(uniforms, expected) {
+		t.Errorf("expected %v but got %v", expected, uniforms)
+	}
+	if _, err := ParseUniforms([]byte("var Foo sampler")); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+}
+
+func TestMaterialValues(t *testing.T) {
+	uniforms, _ := ParseUniforms([]byte(uniformSrc))
+	m := NewMaterialFor(nil, uniforms)
+
+	if err := m.SetVec4("Tint", 1, 0.5, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetVec2("Tint", 1, 1); err == nil {
+		t.Error("expected a type error")
+	}
+	*m.Float("Lights[2].y") = 7
+	if m.Float("Tint.g") == nil || *m.Float("Tint.g") != 0.5 || m.Float("Lights[4]") != nil || m.Float("Nope") != nil {
+		t.Error("unexpected component pointers")
+	}
+	if m.Get("Lights")[7] != 7 {
+		t.Errorf("expected Lights[2].y at index 7 but got %v", m.Get("Lights"))
+	}
+
+	_ = m.SetInt("Mode", 2)
+	values := m.UniformValues()
+	if values["Mode"] != int32(2) || len(values["Lights"].([]float32)) != 12 {
+		t.Errorf("unexpected uniform values %v", values)
+	}
+
+	elapsed := 0.0
+	_ = m.Bind("Time", func() float64 { return elapsed })
+	_ = m.BindTween("Tint.a", tween.New(1, 0, 100*time.Millisecond, tween.Linear))
+	elapsed = 3
+	m.Update(50 * time.Millisecond)
+	if m.Get("Time")[0] != 3 || m.Get("Tint")[3] != 0.5 {
+		t.Errorf("expected bound values but got %v %v", m.Get("Time"), m.Get("Tint"))
+	}
+
+	m.SetShader(nil, uniforms[:2])
+	if m.Get("Tint")[0] != 1 || m.Get("Lights") != nil {
+		t.Error("expected values to survive a shader swap")
+	}
+}
@@ -1,42 +1,42 @@
 package shader
 
 import (
-	_ "embed"
 	"math/rand"
 	"time"
 
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
 	"github.com/weakpixel/ebitenkiso/pkg/timer"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-var (
-	//go:embed kage/noise.kage
-	noiseRaw []byte
-	noise    = mustNewShader(noiseRaw)
-)
-
-func NewNoise(delay time.Duration) *NoiseShader {
-	return &NoiseShader{
-		shader: noise,
-		t:      timer.New(delay),
+func NewNoise(delay time.Duration) (*NoiseShader, error) {
+	compiled, err := kage.Embedded("noise")
+	if err != nil {
+		return nil, err
+	}
+	s := &NoiseShader{
+		shader: compiled,
+		sched:  timer.NewScheduler(),
 	}
+	s.sched.Every(delay, s.reseed)
+	return s, nil
 }
 
 type NoiseShader struct {
 	shader *ebiten.Shader
 	seed   float32
 	Invert bool
-	t      *timer.Timer
+	sched  *timer.Scheduler
+}
+
+func (s *NoiseShader) reseed() {
+	min, max := float32(1.0), float32(8000)
+	s.seed = min + rand.Float32()*(max-min)
 }
 
 func (s *NoiseShader) Update(dt time.Duration) {
-	done := s.t.Update(dt)
-	if done {
-		s.t.Reset()
-		min, max := float32(1.0), float32(8000)
-		s.seed = min + rand.Float32()*(max-min)
-	}
+	s.sched.Update(dt)
 }
 
 func (s *NoiseShader) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
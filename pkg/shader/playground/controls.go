@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/weakpixel/ebitenkiso/pkg/shader"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	panelWidth = 260
+	rowHeight  = 30
+	charWidth  = 6
+)
+
+var (
+	panelColor  = color.RGBA{24, 24, 30, 255}
+	trackColor  = color.RGBA{60, 60, 72, 255}
+	fillColor   = color.RGBA{90, 150, 230, 255}
+	activeColor = color.RGBA{140, 190, 255, 255}
+)
+
+// control edits one component of a uniform.
+type control struct {
+	// name addresses the component for Material.Float, e.g. "Value.x".
+	name     string
+	min, max float64
+	def      float64
+	isInt    bool
+	toggle   bool
+	// position is the axis of a vec2 that follows the cursor, -1 for none.
+	position int
+	// bound controls are driven by the playground, e.g. Time.
+	bound bool
+}
+
+// hint holds the options from the comment of a uniform declaration:
+//
+//	var Strength float // range: 0, 4; default: 1
+//	var Invert float   // toggle
+type hint struct {
+	hasRange bool
+	min, max float64
+	defaults []float64
+	toggle   bool
+}
+
+var toggleNames = []string{"Use", "Enable", "Invert", "Is", "Has", "Mix", "Show"}
+
+func parseHints(src []byte, uniforms []shader.Uniform) map[string]hint {
+	decls := make([]*regexp.Regexp, len(uniforms))
+	for idx, u := range uniforms {
+		decls[idx] = declaration(u.Name)
+	}
+	hints := map[string]hint{}
+	for _, line := range strings.Split(string(src), "\n") {
+		code, comment, ok := strings.Cut(line, "//")
+		if !ok {
+			continue
+		}
+		for idx, u := range uniforms {
+			if _, done := hints[u.Name]; done || !decls[idx].MatchString(code) {
+				continue
+			}
+			hints[u.Name] = parseHint(comment)
+		}
+	}
+	return hints
+}
+
+// declaration matches the code of a line declaring name, alone or in a
+// list like "var A, B float".
+func declaration(name string) *regexp.Regexp {
+	return regexp.MustCompile(`^\s*(var\s+)?([\w\s,]*,\s*)?` + regexp.QuoteMeta(name) + `\b`)
+}
+
+func parseHint(comment string) hint {
+	h := hint{}
+	for _, part := range strings.Split(comment, ";") {
+		key, val, _ := strings.Cut(strings.TrimSpace(part), ":")
+		switch strings.TrimSpace(key) {
+		case "toggle":
+			h.toggle = true
+		case "range":
+			v := parseFloats(val)
+			if len(v) == 2 {
+				h.hasRange, h.min, h.max = true, v[0], v[1]
+			}
+		case "default":
+			h.defaults = parseFloats(val)
+		}
+	}
+	return h
+}
+
+func parseFloats(s string) []float64 {
+	var out []float64
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// newControls creates a control for every component of the uniforms, mat
+// uniforms are not editable. Positions range over the source image of
+// size w x h.
+func newControls(uniforms []shader.Uniform, hints map[string]hint, w, h int) []control {
+	var controls []control
+	for _, u := range uniforms {
+		if strings.HasPrefix(u.Type, "mat") {
+			continue
+		}
+		hi := hints[u.Name]
+		idx := 0
+		for e := range max(u.Len, 1) {
+			for c := range u.Size() {
+				ctl := control{
+					name:     componentName(u, e, c),
+					min:      0,
+					max:      1,
+					isInt:    u.Int(),
+					position: -1,
+				}
+				switch {
+				case u.Name == "Time" && u.Type == "float":
+					ctl.bound = true
+				case hi.toggle || u.Size() == 1 && hasToggleName(u.Name):
+					ctl.toggle = true
+				case u.Type == "vec2" && !hi.hasRange:
+					ctl.position = c
+					ctl.max = float64([]int{w, h}[c])
+					ctl.def = ctl.max / 2
+				case ctl.isInt:
+					ctl.max = 16
+				}
+				if hi.hasRange {
+					ctl.min, ctl.max = hi.min, hi.max
+				}
+				if idx < len(hi.defaults) {
+					ctl.def = hi.defaults[idx]
+				} else if ctl.position < 0 {
+					ctl.def = math.Max(ctl.min, math.Min(0, ctl.max))
+				}
+				controls = append(controls, ctl)
+				idx++
+			}
+		}
+	}
+	return controls
+}
+
+func componentName(u shader.Uniform, elem, comp int) string {
+	name := u.Name
+	if u.Len > 0 {
+		name += fmt.Sprintf("[%d]", elem)
+	}
+	if u.Size() > 1 {
+		name += "." + string("xyzw"[comp])
+	}
+	return name
+}
+
+func hasToggleName(name string) bool {
+	for _, prefix := range toggleNames {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// set writes v clamped to the range of the control.
+func (c *control) set(m *shader.Material, v float64) {
+	p := m.Float(c.name)
+	if p == nil {
+		return
+	}
+	if c.toggle {
+		*p = v
+		return
+	}
+	v = math.Max(c.min, math.Min(c.max, v))
+	if c.isInt {
+		v = math.Round(v)
+	}
+	*p = v
+}
+
+func (c *control) value(m *shader.Material) float64 {
+	if p := m.Float(c.name); p != nil {
+		return *p
+	}
+	return 0
+}
+
+// panel lays out the controls in a column at the right of the window.
+type panel struct {
+	x, y   int
+	scroll int
+	active int
+}
+
+func (p *panel) top() int {
+	return p.y + 56 - p.scroll
+}
+
+// rowAt returns the control at the cursor, -1 if there is none.
+func (p *panel) rowAt(x, y, n int) int {
+	if x < p.x || y < p.y+56 {
+		return -1
+	}
+	row := (y - p.top()) / rowHeight
+	if y < p.top() || row >= n {
+		return -1
+	}
+	return row
+}
+
+// valueAt converts the cursor x to a value of c.
+func (p *panel) valueAt(c *control, x int) float64 {
+	t := float64(x-p.x-10) / float64(panelWidth-20)
+	t = math.Max(0, math.Min(1, t))
+	return c.min + t*(c.max-c.min)
+}
+
+func (p *panel) draw(screen *ebiten.Image, m *shader.Material, controls []control, header []string) {
+	h := screen.Bounds().Dy()
+	vector.FillRect(screen, float32(p.x), float32(p.y), panelWidth, float32(h-p.y), panelColor, false)
+	for idx, c := range controls {
+		y := p.top() + idx*rowHeight
+		if y < p.y+56-rowHeight || y > h {
+			continue
+		}
+		v := c.value(m)
+		label := c.name + ": " + formatValue(c, v)
+		if c.bound {
+			label += " (auto)"
+		}
+		if c.position >= 0 {
+			label += " (right click)"
+		}
+		x := float32(p.x + 10)
+		switch {
+		case c.toggle:
+			vector.StrokeRect(screen, x, float32(y+4), 12, 12, 1, trackColor, false)
+			if v != 0 {
+				vector.FillRect(screen, x+3, float32(y+7), 6, 6, fillColor, false)
+			}
+			ebitenutil.DebugPrintAt(screen, c.name, p.x+30, y+1)
+		case c.bound:
+			ebitenutil.DebugPrintAt(screen, label, p.x+10, y)
+		default:
+			ebitenutil.DebugPrintAt(screen, label, p.x+10, y)
+			t := 0.0
+			if c.max != c.min {
+				t = (v - c.min) / (c.max - c.min)
+			}
+			t = math.Max(0, math.Min(1, t))
+			clr := fillColor
+			if idx == p.active {
+				clr = activeColor
+			}
+			vector.FillRect(screen, x, float32(y+18), panelWidth-20, 6, trackColor, false)
+			vector.FillRect(screen, x, float32(y+18), float32(t*(panelWidth-20)), 6, clr, false)
+		}
+	}
+	vector.FillRect(screen, float32(p.x), float32(p.y), panelWidth, 56, panelColor, false)
+	for idx, line := range header {
+		ebitenutil.DebugPrintAt(screen, truncate(line, (panelWidth-20)/charWidth), p.x+10, p.y+4+idx*16)
+	}
+}
+
+func formatValue(c control, v float64) string {
+	if c.isInt || c.toggle {
+		return strconv.Itoa(int(v))
+	}
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:max(n-3, 0)] + "..."
+}
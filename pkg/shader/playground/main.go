@@ -0,0 +1,408 @@
+// Command playground runs a Kage shader on an image with on-screen controls
+// for its uniforms and reloads it whenever the file or one of its partials
+// is saved:
+//
+//	go run ./pkg/shader/playground -image assets/hero.png effect.kage
+//
+// Controls are generated from the uniform declarations. vec2 uniforms are
+// treated as positions and follow the cursor while the right mouse button
+// is held, a float named Time runs with the clock. Comments on the
+// declaration line tune the controls:
+//
+//	var Strength float // range: 0, 4; default: 1
+//	var Invert float   // toggle
+//
+// Keys: space shows the source image, S saves a screenshot, R resets the
+// uniforms, F5 reloads, tab hides the panel.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+	"github.com/weakpixel/ebitenkiso/pkg/shader"
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
+	"github.com/weakpixel/ebitenkiso/pkg/ticker"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	imageFlag = flag.String("image", filepath.Join("pkg", "shader", "playground", "shader-test.png"), "source image, a test pattern if empty")
+	extra     = [3]*string{
+		flag.String("image1", "", "image passed as imageSrc1"),
+		flag.String("image2", "", "image passed as imageSrc2"),
+		flag.String("image3", "", "image passed as imageSrc3"),
+	}
+	scaleFlag = flag.Int("scale", 3, "initial window scale")
+	outFlag   = flag.String("out", ".", "directory for screenshots")
+	interval  = flag.Duration("interval", 500*time.Millisecond, "how often the files are checked for changes")
+
+	errorColor = color.RGBA{40, 0, 0, 220}
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: playground [flags] [file.kage]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	file := filepath.Join("pkg", "shader", "kage", "test.kage")
+	if flag.NArg() > 0 {
+		file = flag.Arg(0)
+	}
+	r, err := res.Parse(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := loadImage(*imageFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g := &Game{
+		file:    r,
+		name:    strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+		loader:  kage.NewLoader(),
+		ticker:  ticker.Time(),
+		src:     src,
+		frame:   ebiten.NewImage(src.Bounds().Dx(), src.Bounds().Dy()),
+		enabled: true,
+		panel:   &panel{active: -1},
+	}
+	for idx, p := range extra {
+		if *p == "" {
+			continue
+		}
+		if g.images[idx], err = loadImage(*p); err != nil {
+			log.Fatal(err)
+		}
+	}
+	g.load()
+
+	w, h := src.Bounds().Dx()*(*scaleFlag), src.Bounds().Dy()*(*scaleFlag)
+	ebiten.SetWindowSize(w+panelWidth, max(h, 360))
+	ebiten.SetWindowTitle("Shader Playground - " + file)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	if err := ebiten.RunGame(g); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadImage(path string) (*ebiten.Image, error) {
+	if path == "" {
+		return pattern(320, 180), nil
+	}
+	r, err := res.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return res.Image(r)
+}
+
+// pattern is a gradient with a checker board, used without -image.
+func pattern(w, h int) *ebiten.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{uint8(x * 255 / w), uint8(y * 255 / h), 64, 255}
+			if (x/16+y/16)%2 == 0 {
+				c.B = 224
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return ebiten.NewImageFromImage(img)
+}
+
+type Game struct {
+	file     res.Resource
+	name     string
+	loader   *kage.Loader
+	live     *kage.Live
+	mat      *shader.Material
+	err      error
+	ticker   ticker.Ticker
+	elapsed  time.Duration
+	src      *ebiten.Image
+	images   [3]*ebiten.Image
+	frame    *ebiten.Image
+	uniforms []shader.Uniform
+	controls []control
+	panel    *panel
+	enabled  bool
+	hidden   bool
+	status   string
+	statusAt time.Duration
+	width    int
+	height   int
+}
+
+// load starts watching the shader, when the first compile fails the
+// material is created with the first successful reload.
+func (g *Game) load() {
+	live, err := g.loader.Watch(g.file, nil)
+	g.live = live
+	live.SetInterval(*interval)
+	live.OnError = g.reportError
+	live.OnReload = func(*ebiten.Shader, *kage.Source) {
+		if g.mat == nil {
+			g.attach()
+			return
+		}
+		log.Printf("%s reloaded", g.file)
+		g.setStatus("reloaded")
+		g.refresh()
+	}
+	if err != nil {
+		g.reportError(err)
+		return
+	}
+	g.attach()
+}
+
+// attach creates the material for the compiled shader.
+func (g *Game) attach() {
+	mat, err := shader.NewLiveMaterial(g.live)
+	if err != nil {
+		g.err = err
+		g.reportError(err)
+		return
+	}
+	g.mat, g.err = mat, nil
+	mat.Images = g.images
+	g.refresh()
+}
+
+func (g *Game) reportError(err error) {
+	log.Println(err)
+	g.setStatus("compile failed")
+}
+
+func (g *Game) setStatus(s string) {
+	g.status = s
+	g.statusAt = g.elapsed
+}
+
+// refresh rebuilds the controls when the uniforms changed, new uniforms
+// start at their default.
+func (g *Game) refresh() {
+	uniforms := g.mat.Uniforms()
+	if slices.Equal(uniforms, g.uniforms) && g.controls != nil {
+		return
+	}
+	known := map[string]bool{}
+	for _, c := range g.controls {
+		known[c.name] = true
+	}
+	b := g.src.Bounds()
+	g.uniforms = uniforms
+	g.controls = newControls(uniforms, parseHints(g.live.Source().Code, uniforms), b.Dx(), b.Dy())
+	for idx := range g.controls {
+		c := &g.controls[idx]
+		if !known[c.name] {
+			c.set(g.mat, c.def)
+		}
+		if c.bound {
+			g.mat.Bind(c.name, func() float64 { return g.elapsed.Seconds() })
+		}
+	}
+	g.panel.active = -1
+}
+
+func (g *Game) reset() {
+	for idx := range g.controls {
+		g.controls[idx].set(g.mat, g.controls[idx].def)
+	}
+}
+
+// lastError returns the error of the last reload or of the material.
+func (g *Game) lastError() error {
+	if err := g.live.Err(); err != nil {
+		return err
+	}
+	return g.err
+}
+
+func (g *Game) Update() error {
+	dt := g.ticker.Tick()
+	g.elapsed += dt
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		g.enabled = !g.enabled
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		g.hidden = !g.hidden
+	case inpututil.IsKeyJustPressed(ebiten.KeyS):
+		g.screenshot()
+	case inpututil.IsKeyJustPressed(ebiten.KeyR) && g.mat != nil:
+		g.reset()
+	case inpututil.IsKeyJustPressed(ebiten.KeyF5):
+		g.live.Reload()
+	}
+	g.live.Update(dt)
+	if g.mat == nil {
+		return nil
+	}
+	g.input()
+	g.mat.Update(dt)
+	return nil
+}
+
+func (g *Game) input() {
+	x, y := ebiten.CursorPosition()
+	p := g.panel
+	if !g.hidden {
+		_, wy := ebiten.Wheel()
+		maxScroll := max(len(g.controls)*rowHeight-(g.height-56), 0)
+		p.scroll = min(max(p.scroll-int(wy*rowHeight), 0), maxScroll)
+
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			p.active = p.rowAt(x, y, len(g.controls))
+			if p.active >= 0 && g.controls[p.active].toggle {
+				c := &g.controls[p.active]
+				c.set(g.mat, 1-min(c.value(g.mat), 1))
+				p.active = -1
+			}
+		}
+		if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			p.active = -1
+		}
+		if p.active >= 0 && !g.controls[p.active].bound {
+			c := &g.controls[p.active]
+			c.set(g.mat, p.valueAt(c, x))
+		}
+	}
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
+		scale, ox, oy := g.preview()
+		pos := [2]float64{(float64(x) - ox) / scale, (float64(y) - oy) / scale}
+		for idx := range g.controls {
+			if c := &g.controls[idx]; c.position >= 0 {
+				c.set(g.mat, pos[c.position])
+			}
+		}
+	}
+}
+
+// preview returns the scale and offset of the frame in the window.
+func (g *Game) preview() (float64, float64, float64) {
+	w := g.width
+	if !g.hidden {
+		w -= panelWidth
+	}
+	b := g.frame.Bounds()
+	scale := min(float64(w)/float64(b.Dx()), float64(g.height)/float64(b.Dy()))
+	scale = max(scale, 0.1)
+	ox := (float64(w) - float64(b.Dx())*scale) / 2
+	oy := (float64(g.height) - float64(b.Dy())*scale) / 2
+	return scale, ox, oy
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	g.frame.Clear()
+	if g.mat != nil && g.enabled {
+		g.mat.Draw(g.src, g.frame, &ebiten.DrawRectShaderOptions{})
+	} else {
+		g.frame.DrawImage(g.src, nil)
+	}
+	scale, ox, oy := g.preview()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(ox, oy)
+	screen.DrawImage(g.frame, op)
+
+	if err := g.lastError(); err != nil {
+		g.drawError(screen, err)
+	}
+	if !g.hidden {
+		g.panel.x, g.panel.y = g.width-panelWidth, 0
+		header := []string{
+			g.name + ".kage",
+			fmt.Sprintf("TPS: %0.1f", ebiten.ActualTPS()),
+			"space S R F5 tab",
+		}
+		if g.status != "" && g.elapsed-g.statusAt < 3*time.Second {
+			header[2] = g.status
+		}
+		g.panel.draw(screen, g.mat, g.controls, header)
+	}
+}
+
+// drawError shows the messages of err over the bottom of the preview, for
+// compile errors with the offending source lines.
+func (g *Game) drawError(screen *ebiten.Image, err error) {
+	lines := errorLines(err)
+	if g.mat != nil {
+		lines = append([]string{"still running the last working version"}, lines...)
+	}
+	w := g.width
+	if !g.hidden {
+		w -= panelWidth
+	}
+	h := len(lines)*16 + 8
+	y := g.height - h
+	vector.FillRect(screen, 0, float32(y), float32(w), float32(h), errorColor, false)
+	for idx, line := range lines {
+		ebitenutil.DebugPrintAt(screen, truncate(line, (w-8)/charWidth), 4, y+4+idx*16)
+	}
+}
+
+func errorLines(err error) []string {
+	var cerr *kage.CompileError
+	if !errors.As(err, &cerr) {
+		return strings.Split(err.Error(), "\n")
+	}
+	lines := []string{fmt.Sprintf("compile failed, shader %q", cerr.Shader)}
+	for _, i := range cerr.Issues {
+		lines = append(lines, i.String())
+		if i.Source != "" {
+			lines = append(lines, fmt.Sprintf("%5d | %s", i.Line, strings.ReplaceAll(i.Source, "\t", "    ")))
+		}
+	}
+	return lines
+}
+
+// screenshot writes the shader output at the size of the source image.
+func (g *Game) screenshot() {
+	b := g.frame.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	g.frame.ReadPixels(img.Pix)
+	name := filepath.Join(*outFlag, fmt.Sprintf("%s-%s.png", g.name, time.Now().Format("20060102-150405")))
+	if err := writePNG(name, img); err != nil {
+		log.Println(err)
+		g.setStatus("screenshot failed")
+		return
+	}
+	log.Printf("saved %s", name)
+	g.setStatus("saved " + filepath.Base(name))
+}
+
+func writePNG(name string, img image.Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("png.Encode failed: %w", err)
+	}
+	return f.Close()
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	g.width, g.height = outsideWidth, outsideHeight
+	return outsideWidth, outsideHeight
+}
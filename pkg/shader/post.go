@@ -0,0 +1,175 @@
+package shader
+
+import (
+	"image"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Pass is a step of a Pipeline.
+type Pass struct {
+	Shader  Shader
+	Enabled bool
+	// Scale renders the pass at a fraction of the source size, e.g. 0.5 for
+	// a cheap blur. 0 and 1 render at full size.
+	Scale float64
+}
+
+func (p *Pass) size(w, h int) (int, int) {
+	if p.Scale <= 0 || p.Scale == 1 {
+		return w, h
+	}
+	return max(int(float64(w)*p.Scale), 1), max(int(float64(h)*p.Scale), 1)
+}
+
+// NewPipeline chains shaders, each one reads the result of the previous.
+func NewPipeline(shaders ...Shader) *Pipeline {
+	p := &Pipeline{
+		buffers: map[image.Point][]*ebiten.Image{},
+		used:    map[image.Point]int{},
+	}
+	for _, s := range shaders {
+		p.Add(s)
+	}
+	return p
+}
+
+// Pipeline is a post-processing chain. Intermediate results are rendered
+// into buffers kept across frames, the source image is never written.
+type Pipeline struct {
+	Passes  []*Pass
+	buffers map[image.Point][]*ebiten.Image
+	used    map[image.Point]int
+}
+
+// Add appends an enabled full size pass.
+func (p *Pipeline) Add(s Shader) *Pass {
+	pass := &Pass{
+		Shader:  s,
+		Enabled: true,
+	}
+	p.Passes = append(p.Passes, pass)
+	return pass
+}
+
+func (p *Pipeline) Update(dt time.Duration) {
+	for _, pass := range p.Passes {
+		if pass.Enabled {
+			pass.Shader.Update(dt)
+		}
+	}
+}
+
+// Draw runs srcImage through all enabled passes and draws the result to
+// screen with op. Without enabled passes srcImage is drawn as is.
+func (p *Pipeline) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
+	if op == nil {
+		op = &ebiten.DrawRectShaderOptions{}
+	}
+	clear(p.used)
+	passes := make([]*Pass, 0, len(p.Passes))
+	for _, pass := range p.Passes {
+		if pass.Enabled {
+			passes = append(passes, pass)
+		}
+	}
+
+	w, h := srcImage.Bounds().Dx(), srcImage.Bounds().Dy()
+	cur := srcImage
+	for idx, pass := range passes {
+		pw, ph := pass.size(w, h)
+		input := p.resample(cur, pw, ph)
+		if idx == len(passes)-1 && pw == w && ph == h {
+			pass.Shader.Draw(input, screen, op)
+			return
+		}
+		out := p.buffer(pw, ph)
+		pass.Shader.Draw(input, out, &ebiten.DrawRectShaderOptions{})
+		cur = out
+	}
+
+	dop := &ebiten.DrawImageOptions{}
+	cw, ch := cur.Bounds().Dx(), cur.Bounds().Dy()
+	dop.GeoM.Scale(float64(w)/float64(cw), float64(h)/float64(ch))
+	dop.GeoM.Concat(op.GeoM)
+	dop.ColorScale = op.ColorScale
+	dop.Blend = op.Blend
+	dop.Filter = ebiten.FilterLinear
+	screen.DrawImage(cur, dop)
+}
+
+// resample returns img scaled into a buffer of w x h, or img if it already
+// has that size.
+func (p *Pipeline) resample(img *ebiten.Image, w, h int) *ebiten.Image {
+	iw, ih := img.Bounds().Dx(), img.Bounds().Dy()
+	if iw == w && ih == h {
+		return img
+	}
+	out := p.buffer(w, h)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(w)/float64(iw), float64(h)/float64(ih))
+	op.Filter = ebiten.FilterLinear
+	out.DrawImage(img, op)
+	return out
+}
+
+// buffer returns a cleared image of w x h. Each result is only read by the
+// next step of the chain, so two buffers per size are enough.
+func (p *Pipeline) buffer(w, h int) *ebiten.Image {
+	size := image.Pt(w, h)
+	idx := p.used[size] % 2
+	p.used[size]++
+	list := p.buffers[size]
+	if idx < len(list) {
+		list[idx].Clear()
+		return list[idx]
+	}
+	img := ebiten.NewImage(w, h)
+	p.buffers[size] = append(list, img)
+	return img
+}
+
+// Dispose releases the buffers, e.g. after the screen size changed.
+func (p *Pipeline) Dispose() {
+	for _, list := range p.buffers {
+		for _, img := range list {
+			img.Deallocate()
+		}
+	}
+	p.buffers = map[image.Point][]*ebiten.Image{}
+}
+
+// NewScreen returns a Screen taking the frame through shaders.
+func NewScreen(shaders ...Shader) *Screen {
+	return &Screen{
+		Pipeline: NewPipeline(shaders...),
+	}
+}
+
+// Screen renders the whole game into an offscreen frame and presents it
+// through a Pipeline:
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		g.post.Draw(screen, g.drawWorld)
+//	}
+type Screen struct {
+	*Pipeline
+	frame *ebiten.Image
+}
+
+// Draw clears the frame, lets draw render into it and presents it on
+// screen.
+func (s *Screen) Draw(screen *ebiten.Image, draw func(frame *ebiten.Image)) {
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+	if s.frame == nil || s.frame.Bounds().Dx() != w || s.frame.Bounds().Dy() != h {
+		if s.frame != nil {
+			s.frame.Deallocate()
+			s.Dispose()
+		}
+		s.frame = ebiten.NewImage(w, h)
+	}
+	s.frame.Clear()
+	draw(s.frame)
+	s.Pipeline.Draw(s.frame, screen, &ebiten.DrawRectShaderOptions{})
+}
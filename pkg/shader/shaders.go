@@ -1,30 +1,24 @@
 package shader
 
 import (
-	_ "embed"
 	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
-)
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
 
-var (
-	//go:embed kage/default.kage
-	defRaw []byte
-	def    = mustNewShader(defRaw)
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
-func mustNewShader(raw []byte) *ebiten.Shader {
-	s, err := ebiten.NewShader(raw)
+// NewDefault draws the source unchanged. Like the other effects of this
+// package it is compiled from the shaders embedded in package kage on the
+// first call, later calls share the compiled shader.
+func NewDefault() (*DefaultShader, error) {
+	s, err := kage.Embedded("default")
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return s
-}
-
-func NewDefault() *DefaultShader {
 	return &DefaultShader{
-		shader: def,
-	}
+		shader: s,
+	}, nil
 }
 
 type DefaultShader struct {
@@ -46,28 +40,35 @@ func Group(s ...Shader) Shader {
 	}
 }
 
-type ShaderGroup struct {
-	shaders     []Shader
-	bufferImage *ebiten.Image
-	useBuffer   bool
-}
-
-func (s *ShaderGroup) bufferSize() (int, int) {
-	if s.bufferImage == nil {
-		return 0, 0
+// BufferedGroup chains the shaders, each one reads the result of the
+// previous one.
+func BufferedGroup(s ...Shader) *ShaderGroup {
+	g := &ShaderGroup{
+		shaders: s,
 	}
-	return s.bufferImage.Bounds().Dx(), s.bufferImage.Bounds().Dy()
+	g.SetBuffered(true)
+	return g
 }
 
-func (s *ShaderGroup) buffer(srcImage *ebiten.Image) *ebiten.Image {
-	w, h := srcImage.Bounds().Dx(), srcImage.Bounds().Dy()
-	ww, hh := s.bufferSize()
-	if w != ww || hh != h {
-		s.bufferImage = ebiten.NewImage(w, h)
+// ShaderGroup draws all shaders from the same source onto the screen, or
+// buffered as a chain through a Pipeline.
+type ShaderGroup struct {
+	shaders  []Shader
+	pipeline *Pipeline
+}
 
+func (s *ShaderGroup) SetBuffered(buffered bool) {
+	if !buffered {
+		s.pipeline = nil
+		return
+	}
+	if s.pipeline == nil {
+		s.pipeline = NewPipeline(s.shaders...)
 	}
-	return s.bufferImage
+}
 
+func (s *ShaderGroup) Buffered() bool {
+	return s.pipeline != nil
 }
 
 func (s *ShaderGroup) Update(dt time.Duration) {
@@ -77,19 +78,13 @@ func (s *ShaderGroup) Update(dt time.Duration) {
 }
 
 func (s *ShaderGroup) Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions) {
-	if !s.useBuffer {
-		for _, s := range s.shaders {
-			s.Draw(srcImage, screen, op)
-		}
+	if s.pipeline != nil {
+		s.pipeline.Draw(srcImage, screen, op)
 		return
 	}
-
-	buffer := s.buffer(srcImage)
 	for _, s := range s.shaders {
-		s.Draw(srcImage, buffer, op)
-		srcImage.DrawImage(buffer, nil)
+		s.Draw(srcImage, screen, op)
 	}
-	screen.DrawImage(buffer, nil)
 }
 
 type Shader interface {
@@ -0,0 +1,118 @@
+package shadertest
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that makes Golden rewrite the
+// golden images instead of comparing against them:
+//
+//	UPDATE_GOLDEN=1 go test -tags gpu ./pkg/shader/...
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Golden compares img against the PNG at path. Every channel may differ by
+// tolerance, GPUs do not all round the same. A missing golden fails the
+// test, with UPDATE_GOLDEN set the image is written instead. On
+// a mismatch the rendered and the diff image are written next to the
+// golden as <name>.actual.png and <name>.diff.png.
+func Golden(t testing.TB, path string, img image.Image, tolerance uint8) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := writePNG(path, img); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := readPNG(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden image %s is missing, run with %s=1 to create it", path, UpdateEnv)
+	}
+	if err != nil {
+		t.Fatalf("golden image %s: %s", path, err)
+	}
+	n, diff := Diff(expected, img, tolerance)
+	if n == 0 {
+		return
+	}
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	if err := writePNG(base+".actual.png", img); err != nil {
+		t.Error(err)
+	}
+	if diff != nil {
+		if err := writePNG(base+".diff.png", diff); err != nil {
+			t.Error(err)
+		}
+	}
+	b := expected.Bounds()
+	t.Errorf("golden image %s: %d of %d pixels differ by more than %d, see %s.actual.png, run with %s=1 to update",
+		path, n, b.Dx()*b.Dy(), tolerance, base, UpdateEnv)
+}
+
+// Diff returns the number of pixels where a channel differs by more than
+// tolerance and an image marking them in red. Images of different size
+// count all pixels as different and return no image.
+func Diff(expected, actual image.Image, tolerance uint8) (int, *image.RGBA) {
+	eb, ab := expected.Bounds(), actual.Bounds()
+	if eb.Dx() != ab.Dx() || eb.Dy() != ab.Dy() {
+		return max(eb.Dx()*eb.Dy(), ab.Dx()*ab.Dy()), nil
+	}
+	diff := image.NewRGBA(image.Rect(0, 0, eb.Dx(), eb.Dy()))
+	count := 0
+	for y := range eb.Dy() {
+		for x := range eb.Dx() {
+			e := color.RGBAModel.Convert(expected.At(eb.Min.X+x, eb.Min.Y+y)).(color.RGBA)
+			a := color.RGBAModel.Convert(actual.At(ab.Min.X+x, ab.Min.Y+y)).(color.RGBA)
+			if channelDiff(e.R, a.R) > tolerance || channelDiff(e.G, a.G) > tolerance ||
+				channelDiff(e.B, a.B) > tolerance || channelDiff(e.A, a.A) > tolerance {
+				count++
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				continue
+			}
+			// matching pixels are kept faint for orientation
+			diff.SetRGBA(x, y, color.RGBA{a.R / 4, a.G / 4, a.B / 4, 255})
+		}
+	}
+	return count, diff
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("png.Decode failed: %w", err)
+	}
+	return img, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("png.Encode failed: %w", err)
+	}
+	return f.Close()
+}
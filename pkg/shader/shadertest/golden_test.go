@@ -0,0 +1,25 @@
+package shadertest_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/weakpixel/ebitenkiso/pkg/shader/shadertest"
+)
+
+func TestDiff(t *testing.T) {
+	a := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	b := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	b.SetRGBA(1, 1, color.RGBA{3, 0, 0, 0})
+	b.SetRGBA(2, 2, color.RGBA{0, 0, 10, 0})
+	if n, _ := shadertest.Diff(a, b, 3); n != 1 {
+		t.Errorf("diff = %d, want 1", n)
+	}
+	if n, _ := shadertest.Diff(a, b, 0); n != 2 {
+		t.Errorf("diff = %d, want 2", n)
+	}
+	if n, img := shadertest.Diff(a, image.NewRGBA(image.Rect(0, 0, 2, 2)), 0); n != 16 || img != nil {
+		t.Errorf("diff of different sizes = %d", n)
+	}
+}
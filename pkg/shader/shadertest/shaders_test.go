@@ -0,0 +1,98 @@
+//go:build gpu
+
+package shadertest_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/weakpixel/ebitenkiso/pkg/light"
+	"github.com/weakpixel/ebitenkiso/pkg/shader"
+	"github.com/weakpixel/ebitenkiso/pkg/shader/kage"
+	"github.com/weakpixel/ebitenkiso/pkg/shader/shadertest"
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+func TestMain(m *testing.M) {
+	shadertest.Main(m)
+}
+
+func TestBlur(t *testing.T) {
+	tests := []struct {
+		name   string
+		create func(offset float32) (*shader.BlurShader, error)
+		offset float32
+	}{
+		{"blur", shader.NewBlur, 0},
+		{"blur-radial", shader.NewBlurRadial, 0},
+		{"abberation", shader.NewAbberation, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.create(tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.SetPosition(16, 24)
+			img := shadertest.Render(s, shadertest.Pattern(64, 64))
+			shadertest.Golden(t, "testdata/"+tt.name+".png", img, 2)
+		})
+	}
+}
+
+func TestNoise(t *testing.T) {
+	s, err := kage.Embedded("noise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := shadertest.Pattern(64, 64)
+	for _, invert := range []float32{0, 1} {
+		img := shadertest.RenderKage(s, 64, 64, map[string]any{"Seed": float32(1), "Invert": invert}, src)
+		name := "testdata/noise.png"
+		if invert == 1 {
+			name = "testdata/noise-invert.png"
+		}
+		shadertest.Golden(t, name, img, 4)
+	}
+}
+
+func TestGlitch(t *testing.T) {
+	s, err := kage.Embedded("glitch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := shadertest.RenderKage(s, 64, 64, map[string]any{"Value": []float32{-40, 25}}, shadertest.Pattern(64, 64))
+	shadertest.Golden(t, "testdata/glitch.png", img, 2)
+}
+
+func TestDefault(t *testing.T) {
+	s, err := shader.NewDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := shadertest.Pattern(16, 16)
+	img := shadertest.Render(s, src)
+	if n, _ := shadertest.Diff(shadertest.ReadPixels(src), img, 0); n != 0 {
+		t.Errorf("default shader changed %d pixels", n)
+	}
+}
+
+func TestLight(t *testing.T) {
+	lights, err := light.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	box := xmath.Polygon{Points: []xmath.Vector2{{X: -4, Y: -4}, {X: 4, Y: -4}, {X: 4, Y: 4}, {X: -4, Y: 4}}}
+	lights.AddOccluder(&light.Occluder{Polygon: box, Position: xmath.Vector2{X: 36, Y: 32}})
+	lights.Add(light.NewPoint(xmath.Vector2{X: 12, Y: 32}, 56, color.RGBA{255, 220, 160, 255}))
+	lights.Begin(64, 64)
+	img := shadertest.Render(lights, shadertest.Pattern(64, 64))
+	shadertest.Golden(t, "testdata/light.png", img, 2)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Draw to panic for a source of another size than Begin")
+		}
+	}()
+	shadertest.Render(lights, shadertest.Pattern(32, 32))
+}
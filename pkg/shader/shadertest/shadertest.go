@@ -0,0 +1,126 @@
+// Package shadertest renders shaders offscreen and compares the result
+// against golden images. Pixels can only be read back while the game loop
+// runs, so packages using it run their tests from Main:
+//
+//	func TestMain(m *testing.M) {
+//		shadertest.Main(m)
+//	}
+//
+//	func TestBlur(t *testing.T) {
+//		blur, err := shader.NewBlur(0)
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		blur.SetPosition(32, 32)
+//		img := shadertest.Render(blur, shadertest.Pattern(64, 64))
+//		shadertest.Golden(t, "testdata/blur.png", img, 2)
+//	}
+//
+// A window is opened while the tests run, so rendering tests are kept
+// behind the gpu build tag and plain go test skips them:
+//
+//	go test -tags gpu ./pkg/shader/shadertest
+//
+// Headless CI needs a virtual display like xvfb-run for them.
+package shadertest
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/weakpixel/ebitenkiso/pkg/shader"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Main runs the tests of m inside ebiten's game loop and exits with their
+// result.
+func Main(m *testing.M) {
+	g := &game{done: make(chan int, 1)}
+	go func() {
+		g.done <- m.Run()
+	}()
+	ebiten.SetWindowSize(64, 64)
+	ebiten.SetWindowTitle("shadertest")
+	ebiten.SetInitFocused(false)
+	if err := ebiten.RunGame(g); err != nil && !errors.Is(err, ebiten.Termination) {
+		panic(err)
+	}
+	os.Exit(g.code)
+}
+
+type game struct {
+	done chan int
+	code int
+}
+
+func (g *game) Update() error {
+	select {
+	case code := <-g.done:
+		g.code = code
+		return ebiten.Termination
+	default:
+		return nil
+	}
+}
+
+func (g *game) Draw(screen *ebiten.Image) {}
+
+func (g *game) Layout(w, h int) (int, int) {
+	return w, h
+}
+
+// Render draws src with s to an image of the same size and reads it back.
+func Render(s shader.Shader, src *ebiten.Image) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := ebiten.NewImage(w, h)
+	defer dst.Deallocate()
+	s.Draw(src, dst, &ebiten.DrawRectShaderOptions{})
+	return ReadPixels(dst)
+}
+
+// RenderKage draws a w x h rect with s, uniforms and images and reads it
+// back. It covers shaders whose uniforms are random at runtime, like noise.
+func RenderKage(s *ebiten.Shader, w, h int, uniforms map[string]any, images ...*ebiten.Image) *image.RGBA {
+	dst := ebiten.NewImage(w, h)
+	defer dst.Deallocate()
+	op := &ebiten.DrawRectShaderOptions{Uniforms: uniforms}
+	copy(op.Images[:], images)
+	dst.DrawRectShader(w, h, s, op)
+	return ReadPixels(dst)
+}
+
+// ReadPixels copies img into memory, colors are premultiplied like
+// image.RGBA.
+func ReadPixels(img *ebiten.Image) *image.RGBA {
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	img.ReadPixels(out.Pix)
+	return out
+}
+
+// Pattern returns a w x h input image with a color gradient and a checker
+// board, so blurs, offsets and color changes are visible in the output. It
+// is unmanaged and not packed into an atlas, so shaders see the same
+// texture coordinates on every run.
+func Pattern(w, h int) *ebiten.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{
+				R: uint8(x * 255 / max(w-1, 1)),
+				G: uint8(y * 255 / max(h-1, 1)),
+				B: 64,
+				A: 255,
+			}
+			if (x/8+y/8)%2 == 0 {
+				c.B = 224
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return ebiten.NewImageFromImageWithOptions(img, &ebiten.NewImageFromImageOptions{Unmanaged: true})
+}
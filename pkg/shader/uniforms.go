@@ -0,0 +1,114 @@
+package shader
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Uniform describes a uniform variable of a Kage shader.
+type Uniform struct {
+	Name string
+	// Type is the Kage type, e.g. "float", "vec2" or "mat4".
+	Type string
+	// Len is the array length, 0 if the uniform is no array.
+	Len int
+}
+
+var uniformSizes = map[string]int{
+	"float": 1, "vec2": 2, "vec3": 3, "vec4": 4,
+	"int": 1, "ivec2": 2, "ivec3": 3, "ivec4": 4,
+	"mat2": 4, "mat3": 9, "mat4": 16,
+}
+
+// Size returns the number of components of a single element.
+func (u Uniform) Size() int {
+	return uniformSizes[u.Type]
+}
+
+// Count returns the number of components of the whole uniform.
+func (u Uniform) Count() int {
+	return u.Size() * max(u.Len, 1)
+}
+
+// Int reports whether the uniform holds integers.
+func (u Uniform) Int() bool {
+	return strings.HasPrefix(u.Type, "int") || strings.HasPrefix(u.Type, "ivec")
+}
+
+var (
+	lineComment  = regexp.MustCompile(`//[^\n]*`)
+	blockComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	varBlock     = regexp.MustCompile(`(?s)^var\s*\((.*?)\)`)
+	varLine      = regexp.MustCompile(`^var\s+([^\n=]+)`)
+	uniformDecl  = regexp.MustCompile(`^([\w\s,]+?)\s+(\[\s*(\d+)\s*\])?\s*(\w+)\s*;?$`)
+)
+
+// ParseUniforms returns the uniforms of a Kage source in order of
+// declaration, those are the exported variables at package level.
+func ParseUniforms(src []byte) ([]Uniform, error) {
+	code := blockComment.ReplaceAllString(string(src), "")
+	code = lineComment.ReplaceAllString(code, "")
+
+	var decls []string
+	depth := 0
+	for idx := 0; idx < len(code); idx++ {
+		switch code[idx] {
+		case '{':
+			depth++
+			continue
+		case '}':
+			depth--
+			continue
+		}
+		if depth != 0 || !strings.HasPrefix(code[idx:], "var") || idx > 0 && !isSpace(code[idx-1]) {
+			continue
+		}
+		rest := code[idx:]
+		if m := varBlock.FindStringSubmatch(rest); m != nil {
+			decls = append(decls, strings.Split(m[1], "\n")...)
+			idx += len(m[0]) - 1
+		} else if m := varLine.FindStringSubmatch(rest); m != nil {
+			decls = append(decls, m[1])
+			idx += len(m[0]) - 1
+		}
+	}
+
+	var uniforms []Uniform
+	for _, decl := range decls {
+		decl = strings.TrimSpace(decl)
+		if decl == "" {
+			continue
+		}
+		m := uniformDecl.FindStringSubmatch(decl)
+		if m == nil {
+			return nil, fmt.Errorf("cannot parse uniform declaration %q", decl)
+		}
+		typ := m[4]
+		if _, ok := uniformSizes[typ]; !ok {
+			return nil, fmt.Errorf("unsupported uniform type %q in %q", typ, decl)
+		}
+		n := 0
+		if m[3] != "" {
+			n, _ = strconv.Atoi(m[3])
+		}
+		for _, name := range strings.Split(m[1], ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || !unicode.IsUpper(rune(name[0])) {
+				continue
+			}
+			uniforms = append(uniforms, Uniform{
+				Name: name,
+				Type: typ,
+				Len:  n,
+			})
+		}
+	}
+	return uniforms, nil
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == ';'
+}
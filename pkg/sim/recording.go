@@ -0,0 +1,162 @@
+package sim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// UpdateEnv is the environment variable that makes Golden rewrite the
+// golden files instead of comparing against them:
+//
+//	UPDATE_GOLDEN=1 go test ./...
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Tolerance is the relative difference allowed between recorded values.
+var Tolerance = 1e-9
+
+type Recording struct {
+	Names   []string
+	Samples []Sample
+}
+
+type Sample struct {
+	Tick   int
+	Time   time.Duration
+	Values []float64
+}
+
+// Value returns the tracked value of name at the given sample index.
+func (r *Recording) Value(sample int, name string) (float64, bool) {
+	for idx, n := range r.Names {
+		if n == name && sample < len(r.Samples) {
+			return r.Samples[sample].Values[idx], true
+		}
+	}
+	return 0, false
+}
+
+// WriteTo writes the recording as a tab separated table.
+func (r *Recording) WriteTo(w io.Writer) (int64, error) {
+	b := &strings.Builder{}
+	b.WriteString(strings.Join(append([]string{"tick", "time"}, r.Names...), "\t"))
+	b.WriteString("\n")
+	for _, s := range r.Samples {
+		b.WriteString(strconv.Itoa(s.Tick))
+		b.WriteString("\t")
+		b.WriteString(s.Time.String())
+		for _, v := range s.Values {
+			b.WriteString("\t")
+			b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		b.WriteString("\n")
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (r *Recording) String() string {
+	b := &strings.Builder{}
+	r.WriteTo(b)
+	return b.String()
+}
+
+// Parse reads a recording written by WriteTo.
+func Parse(in io.Reader) (*Recording, error) {
+	s := bufio.NewScanner(in)
+	if !s.Scan() {
+		return nil, fmt.Errorf("recording: missing header")
+	}
+	header := strings.Split(s.Text(), "\t")
+	if len(header) < 2 || header[0] != "tick" || header[1] != "time" {
+		return nil, fmt.Errorf("recording: invalid header %q", s.Text())
+	}
+	r := &Recording{Names: header[2:]}
+	line := 1
+	for s.Scan() {
+		line++
+		fields := strings.Split(s.Text(), "\t")
+		if len(fields) != len(header) {
+			return nil, fmt.Errorf("recording line %d: expected %d fields but got %d", line, len(header), len(fields))
+		}
+		sample := Sample{Values: make([]float64, len(r.Names))}
+		var err error
+		if sample.Tick, err = strconv.Atoi(fields[0]); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		if sample.Time, err = time.ParseDuration(fields[1]); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		for idx, f := range fields[2:] {
+			if sample.Values[idx], err = strconv.ParseFloat(f, 64); err != nil {
+				return nil, fmt.Errorf("recording line %d: %w", line, err)
+			}
+		}
+		r.Samples = append(r.Samples, sample)
+	}
+	return r, s.Err()
+}
+
+// Compare returns an error describing the first difference to expected.
+func (r *Recording) Compare(expected *Recording, tolerance float64) error {
+	if strings.Join(r.Names, ",") != strings.Join(expected.Names, ",") {
+		return fmt.Errorf("tracked %v but expected %v", r.Names, expected.Names)
+	}
+	if len(r.Samples) != len(expected.Samples) {
+		return fmt.Errorf("recorded %d ticks but expected %d", len(r.Samples), len(expected.Samples))
+	}
+	for idx, s := range r.Samples {
+		e := expected.Samples[idx]
+		if s.Tick != e.Tick || s.Time != e.Time {
+			return fmt.Errorf("sample %d: tick %d at %s but expected tick %d at %s", idx, s.Tick, s.Time, e.Tick, e.Time)
+		}
+		for vi, v := range s.Values {
+			if !almostEqual(v, e.Values[vi], tolerance) {
+				return fmt.Errorf("tick %d: %s is %v but expected %v", s.Tick, r.Names[vi], v, e.Values[vi])
+			}
+		}
+	}
+	return nil
+}
+
+func almostEqual(a, b, tolerance float64) bool {
+	if a == b {
+		return true
+	}
+	scale := math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	return math.Abs(a-b) <= tolerance*scale
+}
+
+// Golden compares the recording against the golden file at path, with
+// UPDATE_GOLDEN set the file is written instead.
+func (r *Recording) Golden(t testing.TB, path string) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(r.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("golden file missing, run with %s=1 to create it: %s", UpdateEnv, err)
+	}
+	defer f.Close()
+	expected, err := Parse(f)
+	if err != nil {
+		t.Fatalf("golden file %s: %s", path, err)
+	}
+	if err := r.Compare(expected, Tolerance); err != nil {
+		t.Errorf("golden file %s: %s", path, err)
+	}
+}
@@ -0,0 +1,64 @@
+package sim
+
+import (
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/ticker"
+)
+
+// New creates a harness advancing dt per tick.
+func New(dt time.Duration) *Harness {
+	return &Harness{
+		Ticker: ticker.Manual(dt),
+	}
+}
+
+// Harness steps game logic without a window and records tracked values
+// after every tick.
+//
+//	h := sim.New(time.Second / 60)
+//	h.Track("x", func() float64 { return x })
+//	rec := h.Run(120, func(dt time.Duration) { x, _ = move.Update(dt) })
+//	rec.Golden(t, "testdata/move.golden")
+type Harness struct {
+	Ticker *ticker.ManualTicker
+	names  []string
+	tracks []func() float64
+}
+
+// Track records the value returned by fn after every tick as name.
+func (h *Harness) Track(name string, fn func() float64) {
+	h.names = append(h.names, name)
+	h.tracks = append(h.tracks, fn)
+}
+
+// TrackBool records fn as 1 for true and 0 for false.
+func (h *Harness) TrackBool(name string, fn func() bool) {
+	h.Track(name, func() float64 {
+		if fn() {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Run calls update n times with the durations of the Ticker.
+func (h *Harness) Run(n int, update func(dt time.Duration)) *Recording {
+	rec := &Recording{
+		Names: append([]string{}, h.names...),
+	}
+	for range n {
+		dt := h.Ticker.Tick()
+		update(dt)
+		s := Sample{
+			Tick:   h.Ticker.Ticks(),
+			Time:   h.Ticker.Elapsed(),
+			Values: make([]float64, len(h.tracks)),
+		}
+		for idx, fn := range h.tracks {
+			s.Values[idx] = fn()
+		}
+		rec.Samples = append(rec.Samples, s)
+	}
+	return rec
+}
@@ -0,0 +1,53 @@
+package sim
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/timer"
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+func TestGolden(t *testing.T) {
+	move := tween.NewSeq(
+		tween.New(0, 100, 100*time.Millisecond, tween.OutQuad),
+		tween.New(100, 0, 100*time.Millisecond, tween.InQuad),
+	)
+	cooldown := timer.New(50 * time.Millisecond)
+
+	x := 0.0
+	h := New(20 * time.Millisecond)
+	h.Track("x", func() float64 { return x })
+	h.TrackBool("ready", cooldown.Done)
+
+	rec := h.Run(12, func(dt time.Duration) {
+		x, _ = move.Update(dt)
+		cooldown.Update(dt)
+	})
+	rec.Golden(t, "testdata/tween.golden")
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	h := New(10 * time.Millisecond)
+	h.Ticker.Queue(5 * time.Millisecond)
+	v := 0.0
+	h.Track("v", func() float64 { return v })
+	rec := h.Run(3, func(dt time.Duration) { v += dt.Seconds() / 3 })
+
+	parsed, err := Parse(strings.NewReader(rec.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Compare(parsed, 0); err != nil {
+		t.Errorf("round trip changed the recording: %s", err)
+	}
+	if parsed.Samples[0].Time != 5*time.Millisecond || parsed.Samples[2].Time != 25*time.Millisecond {
+		t.Errorf("unexpected sample times %s %s", parsed.Samples[0].Time, parsed.Samples[2].Time)
+	}
+
+	parsed.Samples[1].Values[0] += 0.5
+	if err := rec.Compare(parsed, Tolerance); err == nil {
+		t.Error("expected a difference to be reported")
+	}
+}
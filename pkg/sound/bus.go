@@ -0,0 +1,79 @@
+package sound
+
+import (
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+const (
+	BusMusic = "music"
+	BusSFX   = "sfx"
+	BusUI    = "ui"
+)
+
+func NewBus(name string) *Bus {
+	return &Bus{
+		Name:   name,
+		volume: 1,
+	}
+}
+
+// Bus groups sounds sharing a volume, e.g. music, sfx or ui.
+type Bus struct {
+	Name   string
+	Muted  bool
+	volume float64
+	fade   *tween.Tween
+	onFade func()
+}
+
+func (b *Bus) Volume() float64 {
+	if b.Muted {
+		return 0
+	}
+	return b.volume
+}
+
+// SetVolume sets the volume immediately and cancels a running fade.
+func (b *Bus) SetVolume(volume float64) {
+	b.volume = volume
+	b.fade = nil
+	b.onFade = nil
+}
+
+// FadeTo changes the volume over d, done is called once the fade finished.
+func (b *Bus) FadeTo(volume float64, d time.Duration, easing tween.TweenFunc, done func()) {
+	if d <= 0 {
+		b.SetVolume(volume)
+		if done != nil {
+			done()
+		}
+		return
+	}
+	if easing == nil {
+		easing = tween.Linear
+	}
+	b.fade = tween.New(b.volume, volume, d, easing)
+	b.onFade = done
+}
+
+func (b *Bus) Fading() bool {
+	return b.fade != nil
+}
+
+func (b *Bus) Update(dt time.Duration) {
+	if b.fade == nil {
+		return
+	}
+	val, done := b.fade.Update(dt)
+	b.volume = val
+	if done {
+		onFade := b.onFade
+		b.fade = nil
+		b.onFade = nil
+		if onFade != nil {
+			onFade()
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package sound
+
+import (
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+)
+
+func TestBusFade(t *testing.T) {
+	b := NewBus(BusMusic)
+	done := false
+	b.FadeTo(0, time.Second, tween.Linear, func() { done = true })
+
+	b.Update(500 * time.Millisecond)
+	if v := b.Volume(); v < 0.49 || v > 0.51 {
+		t.Errorf("expected volume 0.5 after half the fade but got %f", v)
+	}
+	if done || !b.Fading() {
+		t.Error("fade must still be running")
+	}
+	b.Update(600 * time.Millisecond)
+	if b.Volume() != 0 {
+		t.Errorf("expected volume 0 but got %f", b.Volume())
+	}
+	if !done || b.Fading() {
+		t.Error("fade must be done")
+	}
+}
+
+func TestBusMute(t *testing.T) {
+	b := NewBus(BusSFX)
+	b.FadeTo(0.5, 0, nil, nil)
+	if b.Volume() != 0.5 {
+		t.Errorf("expected volume 0.5 but got %f", b.Volume())
+	}
+	b.Muted = true
+	if b.Volume() != 0 {
+		t.Errorf("expected muted bus to have volume 0 but got %f", b.Volume())
+	}
+}
@@ -0,0 +1,53 @@
+package sound
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+type audioStream interface {
+	io.Reader
+	Length() int64
+	SampleRate() int
+}
+
+// Decode decodes a .wav, .ogg or .mp3 resource into 32 bit float stereo PCM
+// resampled to sampleRate, ready for audio.Context.NewPlayerF32FromBytes.
+func Decode(r res.Resource, sampleRate int) ([]byte, error) {
+	data, err := res.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var stream audioStream
+	in := bytes.NewReader(data)
+	switch ext := res.Ext(r); ext {
+	case ".wav":
+		stream, err = wav.DecodeF32(in)
+	case ".ogg":
+		stream, err = vorbis.DecodeF32(in)
+	case ".mp3":
+		stream, err = mp3.DecodeF32(in)
+	default:
+		err = fmt.Errorf("unsupported extension %q", ext)
+	}
+	if err != nil {
+		return nil, &res.DecodeError{Resource: r, Format: "audio", Err: err}
+	}
+	var src io.Reader = stream
+	if stream.SampleRate() != sampleRate {
+		src = audio.ResampleReaderF32(stream, stream.Length(), stream.SampleRate(), sampleRate)
+	}
+	pcm, err := io.ReadAll(src)
+	if err != nil {
+		return nil, &res.DecodeError{Resource: r, Format: "audio", Err: err}
+	}
+	return pcm, nil
+}
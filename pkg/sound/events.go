@@ -0,0 +1,66 @@
+package sound
+
+import (
+	"time"
+
+	logger "github.com/weakpixel/ebitenkiso/pkg/log"
+	vm "github.com/weakpixel/ebitenkiso/pkg/vm/lua"
+
+	"github.com/Shopify/go-lua"
+)
+
+// FrameEvents maps animation names to frame indices and the sound played
+// when the frame is entered.
+type FrameEvents map[string]map[int]string
+
+// OnFrame returns a callback for sprites.Sprite.OnFrame playing the sounds
+// configured in events.
+//
+//	sprite.OnFrame = manager.OnFrame(sound.FrameEvents{"run": {1: "step", 5: "step"}})
+func (m *Manager) OnFrame(events FrameEvents) func(anim string, frame int) {
+	return func(anim string, frame int) {
+		name, ok := events[anim][frame]
+		if !ok {
+			return
+		}
+		if err := m.Play(name); err != nil {
+			logger.Errorf("%s", err)
+		}
+	}
+}
+
+// Register exposes the manager to a script environment:
+//
+//	playSound(name)
+//	stopSound(name)
+//	setBusVolume(bus, volume)
+//	fadeBus(bus, volume, seconds)
+func (m *Manager) Register(env *vm.Env) {
+	env.RegisterFn("playSound", func(l *lua.State) int {
+		if name, ok := l.ToString(1); ok {
+			if err := m.Play(name); err != nil {
+				lua.Errorf(l, "%s", err.Error())
+			}
+		}
+		return 0
+	})
+	env.RegisterFn("stopSound", func(l *lua.State) int {
+		if name, ok := l.ToString(1); ok {
+			m.Stop(name)
+		}
+		return 0
+	})
+	env.RegisterFn("setBusVolume", func(l *lua.State) int {
+		name, _ := l.ToString(1)
+		volume, _ := l.ToNumber(2)
+		m.Bus(name).SetVolume(volume)
+		return 0
+	})
+	env.RegisterFn("fadeBus", func(l *lua.State) int {
+		name, _ := l.ToString(1)
+		volume, _ := l.ToNumber(2)
+		seconds, _ := l.ToNumber(3)
+		m.Bus(name).FadeTo(volume, time.Duration(seconds*float64(time.Second)), nil, nil)
+		return 0
+	})
+}
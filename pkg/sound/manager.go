@@ -0,0 +1,172 @@
+package sound
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/res"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// NewManager creates a manager with a master bus and the music, sfx and ui
+// buses.
+func NewManager(ctx *audio.Context) *Manager {
+	m := &Manager{
+		ctx:    ctx,
+		Master: NewBus("master"),
+		sounds: map[string]*Sound{},
+		buses:  map[string]*Bus{},
+	}
+	for _, name := range []string{BusMusic, BusSFX, BusUI} {
+		m.buses[name] = NewBus(name)
+	}
+	return m
+}
+
+type Manager struct {
+	ctx    *audio.Context
+	Master *Bus
+	sounds map[string]*Sound
+	buses  map[string]*Bus
+}
+
+// Sound is decoded audio that can be played on several voices at once.
+type Sound struct {
+	Name   string
+	Bus    string
+	Volume float64
+	Loop   bool
+	// MaxVoices limits concurrent playbacks, the oldest voice is stopped
+	// when the limit is reached. Zero means no limit.
+	MaxVoices int
+	pcm       []byte
+	voices    []*audio.Player
+}
+
+// Playing returns the number of active voices, finished voices are
+// released first.
+func (s *Sound) Playing() int {
+	s.prune()
+	return len(s.voices)
+}
+
+// Bus returns the named bus, it is created on first use.
+func (m *Manager) Bus(name string) *Bus {
+	b, ok := m.buses[name]
+	if !ok {
+		b = NewBus(name)
+		m.buses[name] = b
+	}
+	return b
+}
+
+// Load decodes a .wav, .ogg or .mp3 resource and registers it as name.
+func (m *Manager) Load(name string, r res.Resource, bus string) (*Sound, error) {
+	pcm, err := Decode(r, m.ctx.SampleRate())
+	if err != nil {
+		return nil, err
+	}
+	return m.Add(name, pcm, bus), nil
+}
+
+// Add registers 32 bit float stereo PCM at the context sample rate as name.
+func (m *Manager) Add(name string, pcm []byte, bus string) *Sound {
+	m.Stop(name)
+	s := &Sound{
+		Name:   name,
+		Bus:    bus,
+		Volume: 1,
+		pcm:    pcm,
+	}
+	m.Bus(bus)
+	m.sounds[name] = s
+	return s
+}
+
+func (m *Manager) Sound(name string) *Sound {
+	return m.sounds[name]
+}
+
+// Play starts a new voice of the named sound.
+func (m *Manager) Play(name string) error {
+	s, ok := m.sounds[name]
+	if !ok {
+		return fmt.Errorf("sound %q not found", name)
+	}
+	s.prune()
+	if s.MaxVoices > 0 && len(s.voices) >= s.MaxVoices {
+		s.voices[0].Close()
+		s.voices = s.voices[1:]
+	}
+
+	var p *audio.Player
+	if s.Loop {
+		loop := audio.NewInfiniteLoopF32(bytes.NewReader(s.pcm), int64(len(s.pcm)))
+		var err error
+		p, err = m.ctx.NewPlayerF32(loop)
+		if err != nil {
+			return fmt.Errorf("sound %q: %w", name, err)
+		}
+	} else {
+		p = m.ctx.NewPlayerF32FromBytes(s.pcm)
+	}
+	p.SetVolume(m.volume(s))
+	p.Play()
+	s.voices = append(s.voices, p)
+	return nil
+}
+
+// Stop stops all voices of the named sound.
+func (m *Manager) Stop(name string) {
+	s, ok := m.sounds[name]
+	if !ok {
+		return
+	}
+	for _, p := range s.voices {
+		p.Close()
+	}
+	s.voices = nil
+}
+
+// StopBus stops all sounds played on the bus.
+func (m *Manager) StopBus(bus string) {
+	for name, s := range m.sounds {
+		if s.Bus == bus {
+			m.Stop(name)
+		}
+	}
+}
+
+// Update advances bus fades, applies volumes and releases finished voices.
+func (m *Manager) Update(dt time.Duration) {
+	m.Master.Update(dt)
+	for _, b := range m.buses {
+		b.Update(dt)
+	}
+	for _, s := range m.sounds {
+		s.prune()
+		vol := m.volume(s)
+		for _, p := range s.voices {
+			p.SetVolume(vol)
+		}
+	}
+}
+
+func (m *Manager) volume(s *Sound) float64 {
+	return s.Volume * m.Bus(s.Bus).Volume() * m.Master.Volume()
+}
+
+func (s *Sound) prune() {
+	active := s.voices[:0]
+	for _, p := range s.voices {
+		if p.IsPlaying() {
+			active = append(active, p)
+		} else {
+			p.Close()
+		}
+	}
+	clear(s.voices[len(active):])
+	s.voices = active
+}
@@ -0,0 +1,44 @@
+package spline
+
+import (
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+// Follow moves along path driven by progress, e.g. tween.Progress with any
+// easing or a Sequence going back and forth.
+func Follow(path *Path, progress tween.Updater) *Follower {
+	return &Follower{
+		Path:     path,
+		progress: progress,
+		pos:      path.At(0),
+		angle:    path.Angle(0),
+	}
+}
+
+// Follower is a tween.Updater returning the progress along the path.
+type Follower struct {
+	Path     *Path
+	progress tween.Updater
+	pos      xmath.Vector2
+	angle    float64
+}
+
+func (f *Follower) Update(dt time.Duration) (float64, bool) {
+	u, done := f.progress.Update(dt)
+	f.pos = f.Path.At(u)
+	f.angle = f.Path.Angle(u)
+	return u, done
+}
+
+func (f *Follower) Position() xmath.Vector2 {
+	return f.pos
+}
+
+// Angle returns the direction of the path at the current position in
+// radians, to rotate a sprite along the path.
+func (f *Follower) Angle() float64 {
+	return f.angle
+}
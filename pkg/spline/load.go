@@ -0,0 +1,159 @@
+package spline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+const (
+	TypePolyline   = "polyline"
+	TypeCatmullRom = "catmullrom"
+	TypeBezier     = "bezier"
+)
+
+// Data is the JSON form of a path:
+//
+//	{"type": "catmullrom", "closed": false, "points": [{"x": 0, "y": 0}, {"x": 100, "y": 40}]}
+type Data struct {
+	Type   string          `json:"type"`
+	Closed bool            `json:"closed"`
+	Points []xmath.Vector2 `json:"points"`
+}
+
+// IsType reports whether name is a supported path type, ignoring case.
+func IsType(name string) bool {
+	switch strings.ToLower(name) {
+	case TypePolyline, TypeCatmullRom, TypeBezier:
+		return true
+	}
+	return false
+}
+
+// Shape creates the shape described by d, an empty type is a polyline.
+func (d Data) Shape() (Shape, error) {
+	switch strings.ToLower(d.Type) {
+	case "", TypePolyline:
+		return &Polyline{Points: d.Points, Closed: d.Closed}, nil
+	case TypeCatmullRom:
+		return &CatmullRom{Points: d.Points, Closed: d.Closed}, nil
+	case TypeBezier:
+		if len(d.Points) < 4 || (len(d.Points)-1)%3 != 0 {
+			return nil, fmt.Errorf("bezier needs 3n+1 points but got %d", len(d.Points))
+		}
+		return &Bezier{Points: d.Points}, nil
+	}
+	return nil, fmt.Errorf("unsupported path type %q", d.Type)
+}
+
+// Load reads a path in the JSON form of Data.
+func Load(r io.Reader) (*Path, error) {
+	var d Data
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("json.Decode failed: %w", err)
+	}
+	shape, err := d.Shape()
+	if err != nil {
+		return nil, err
+	}
+	return New(shape), nil
+}
+
+type tiledMap struct {
+	Layers []tiledLayer `json:"layers"`
+}
+
+type tiledLayer struct {
+	Objects []tiledObject `json:"objects"`
+	Layers  []tiledLayer  `json:"layers"`
+}
+
+type tiledObject struct {
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	Class      string          `json:"class"`
+	X          float64         `json:"x"`
+	Y          float64         `json:"y"`
+	Polyline   []xmath.Vector2 `json:"polyline"`
+	Polygon    []xmath.Vector2 `json:"polygon"`
+	Properties []tiledProperty `json:"properties"`
+}
+
+type tiledProperty struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// TiledProperty is the custom property of a Tiled object selecting the path
+// type, e.g. "catmullrom".
+const TiledProperty = "spline"
+
+// pathType returns the type of the path, the spline property or a class (or
+// type) naming a path type. Other classes are game classes like "patrol"
+// and load as a polyline.
+func (o *tiledObject) pathType() string {
+	for _, p := range o.Properties {
+		if p.Name == TiledProperty {
+			s, _ := p.Value.(string)
+			return s
+		}
+	}
+	for _, t := range []string{o.Class, o.Type} {
+		if IsType(t) {
+			return t
+		}
+	}
+	return ""
+}
+
+// LoadTiled reads the polyline or polygon object called name from a Tiled
+// JSON map. Polygons are closed. The type of the path comes from the string
+// property "spline" or a class (or type) like "catmullrom", without either
+// the points are joined by straight lines.
+func LoadTiled(r io.Reader, name string) (*Path, error) {
+	var m tiledMap
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("json.Decode failed: %w", err)
+	}
+	obj := findObject(m.Layers, name)
+	if obj == nil {
+		return nil, fmt.Errorf("tiled object %q not found", name)
+	}
+	d := Data{
+		Type:   obj.pathType(),
+		Points: obj.Polyline,
+	}
+	if len(obj.Polygon) > 0 {
+		d.Points = obj.Polygon
+		d.Closed = true
+	}
+	if len(d.Points) == 0 {
+		return nil, fmt.Errorf("tiled object %q has no polyline or polygon", name)
+	}
+	offset := xmath.Vector2{X: obj.X, Y: obj.Y}
+	for idx := range d.Points {
+		d.Points[idx] = d.Points[idx].Add(offset)
+	}
+	shape, err := d.Shape()
+	if err != nil {
+		return nil, fmt.Errorf("tiled object %q: %w", name, err)
+	}
+	return New(shape), nil
+}
+
+func findObject(layers []tiledLayer, name string) *tiledObject {
+	for _, l := range layers {
+		for idx := range l.Objects {
+			if l.Objects[idx].Name == name {
+				return &l.Objects[idx]
+			}
+		}
+		if obj := findObject(l.Layers, name); obj != nil {
+			return obj
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package spline
+
+import (
+	"math"
+	"sort"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+// DefaultSamples is the number of samples used to measure a shape.
+const DefaultSamples = 256
+
+// New measures shape for constant speed movement.
+func New(shape Shape) *Path {
+	return NewSampled(shape, DefaultSamples)
+}
+
+// NewSampled measures shape with n samples, more samples give a more
+// accurate speed on long or sharply bent curves.
+func NewSampled(shape Shape, n int) *Path {
+	n = max(n, 1)
+	p := &Path{
+		Shape:   shape,
+		lengths: make([]float64, n+1),
+	}
+	prev := shape.Point(0)
+	for idx := 1; idx <= n; idx++ {
+		cur := shape.Point(float64(idx) / float64(n))
+		p.lengths[idx] = p.lengths[idx-1] + prev.Distance(cur)
+		prev = cur
+	}
+	return p
+}
+
+// Path is a shape parameterized by arc length.
+type Path struct {
+	Shape   Shape
+	lengths []float64
+}
+
+func (p *Path) Length() float64 {
+	return p.lengths[len(p.lengths)-1]
+}
+
+// param converts a distance along the path to the parameter of the shape.
+func (p *Path) param(dist float64) float64 {
+	n := len(p.lengths) - 1
+	dist = xmath.Clamp(dist, 0, p.Length())
+	idx := sort.SearchFloat64s(p.lengths, dist)
+	if idx == 0 {
+		return 0
+	}
+	a, b := p.lengths[idx-1], p.lengths[idx]
+	local := 0.0
+	if b > a {
+		local = (dist - a) / (b - a)
+	}
+	return (float64(idx-1) + local) / float64(n)
+}
+
+// At returns the point at progress u from 0 to 1, equal steps of u move
+// equal distances.
+func (p *Path) At(u float64) xmath.Vector2 {
+	return p.Shape.Point(p.param(u * p.Length()))
+}
+
+// AtDistance returns the point dist units from the start.
+func (p *Path) AtDistance(dist float64) xmath.Vector2 {
+	return p.Shape.Point(p.param(dist))
+}
+
+// Tangent returns the normalized direction of the path at progress u.
+func (p *Path) Tangent(u float64) xmath.Vector2 {
+	const eps = 1e-4
+	t := p.param(u * p.Length())
+	a, b := p.Shape.Point(math.Max(t-eps, 0)), p.Shape.Point(math.Min(t+eps, 1))
+	return b.Sub(a).Normalize()
+}
+
+// Angle returns the angle of the tangent at progress u in radians.
+func (p *Path) Angle(u float64) float64 {
+	return p.Tangent(u).Angle()
+}
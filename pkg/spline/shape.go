@@ -0,0 +1,104 @@
+package spline
+
+import (
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+// Shape is a curve evaluated by a parameter from 0 to 1. The parameter is
+// not proportional to the distance travelled, wrap a shape in a Path to
+// move at constant speed.
+type Shape interface {
+	Point(t float64) xmath.Vector2
+}
+
+// Polyline connects points with straight lines, closed adds a line back to
+// the first point.
+type Polyline struct {
+	Points []xmath.Vector2
+	Closed bool
+}
+
+func (p *Polyline) Point(t float64) xmath.Vector2 {
+	points := closePoints(p.Points, p.Closed)
+	if len(points) == 0 {
+		return xmath.Vector2{}
+	}
+	seg, local := segment(t, len(points)-1)
+	if seg < 0 {
+		return points[0]
+	}
+	return xmath.Lerp(points[seg], points[seg+1], local)
+}
+
+// CatmullRom is a smooth curve through all points.
+type CatmullRom struct {
+	Points []xmath.Vector2
+	Closed bool
+}
+
+func (c *CatmullRom) Point(t float64) xmath.Vector2 {
+	n := len(c.Points)
+	if n == 0 {
+		return xmath.Vector2{}
+	}
+	segs := n - 1
+	if c.Closed {
+		segs = n
+	}
+	seg, local := segment(t, segs)
+	if seg < 0 {
+		return c.Points[0]
+	}
+	at := func(idx int) xmath.Vector2 {
+		if c.Closed {
+			return c.Points[(idx%n+n)%n]
+		}
+		return c.Points[xmath.Clamp(idx, 0, n-1)]
+	}
+	p0, p1, p2, p3 := at(seg-1), at(seg), at(seg+1), at(seg+2)
+	t2, t3 := local*local, local*local*local
+	f := func(a, b, c, d float64) float64 {
+		return 0.5 * (2*b + (c-a)*local + (2*a-5*b+4*c-d)*t2 + (3*b-a-3*c+d)*t3)
+	}
+	return xmath.Vector2{
+		X: f(p0.X, p1.X, p2.X, p3.X),
+		Y: f(p0.Y, p1.Y, p2.Y, p3.Y),
+	}
+}
+
+// Bezier is a chain of cubic Bézier segments. Points holds the start point
+// followed by two control points and an end point per segment.
+type Bezier struct {
+	Points []xmath.Vector2
+}
+
+func (b *Bezier) Point(t float64) xmath.Vector2 {
+	if len(b.Points) < 4 {
+		return (&Polyline{Points: b.Points}).Point(t)
+	}
+	seg, local := segment(t, (len(b.Points)-1)/3)
+	p := b.Points[seg*3 : seg*3+4]
+	inv := 1 - local
+	return p[0].Mul(inv * inv * inv).
+		Add(p[1].Mul(3 * inv * inv * local)).
+		Add(p[2].Mul(3 * inv * local * local)).
+		Add(p[3].Mul(local * local * local))
+}
+
+// segment splits t into a segment index and the position inside it,
+// -1 if there are no segments.
+func segment(t float64, segs int) (int, float64) {
+	if segs <= 0 {
+		return -1, 0
+	}
+	t = xmath.Clamp(t, 0, 1) * float64(segs)
+	seg := min(int(t), segs-1)
+	return seg, t - float64(seg)
+}
+
+func closePoints(points []xmath.Vector2, closed bool) []xmath.Vector2 {
+	if !closed || len(points) < 2 {
+		return points
+	}
+	return append(points[:len(points):len(points)], points[0])
+}
@@ -0,0 +1,129 @@
+package spline
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/tween"
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+func near(a, b xmath.Vector2, tol float64) bool {
+	return a.Distance(b) < tol
+}
+
+func TestPolylineConstantSpeed(t *testing.T) {
+	// the first segment is 9 times longer than the second
+	p := New(&Polyline{Points: []xmath.Vector2{{X: 0, Y: 0}, {X: 90, Y: 0}, {X: 90, Y: 10}}})
+	if math.Abs(p.Length()-100) > 1e-9 {
+		t.Fatalf("expected length 100 but got %f", p.Length())
+	}
+	if pos := p.At(0.5); !near(pos, xmath.Vector2{X: 50}, 1e-6) {
+		t.Errorf("expected {50 0} at the middle but got %v", pos)
+	}
+	if pos := p.At(0.95); !near(pos, xmath.Vector2{X: 90, Y: 5}, 1e-6) {
+		t.Errorf("expected {90 5} but got %v", pos)
+	}
+	if a := p.Angle(0.95); math.Abs(a-math.Pi/2) > 1e-6 {
+		t.Errorf("expected the tangent to point down but got %f", a)
+	}
+}
+
+func TestCatmullRomPassesPoints(t *testing.T) {
+	points := []xmath.Vector2{{X: 0, Y: 0}, {X: 50, Y: 30}, {X: 100, Y: 0}}
+	c := &CatmullRom{Points: points}
+	for idx, p := range points {
+		if got := c.Point(float64(idx) / 2); !near(got, p, 1e-9) {
+			t.Errorf("expected point %d at %v but got %v", idx, p, got)
+		}
+	}
+	closed := &CatmullRom{Points: points, Closed: true}
+	if got := closed.Point(1); !near(got, points[0], 1e-9) {
+		t.Errorf("expected a closed curve to end at the start but got %v", got)
+	}
+}
+
+func TestBezier(t *testing.T) {
+	b := &Bezier{Points: []xmath.Vector2{{X: 0}, {X: 0, Y: 100}, {X: 100, Y: 100}, {X: 100}}}
+	if got := b.Point(0.5); !near(got, xmath.Vector2{X: 50, Y: 75}, 1e-9) {
+		t.Errorf("expected {50 75} but got %v", got)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	p := New(&Polyline{Points: []xmath.Vector2{{X: 0}, {X: 100}}})
+	f := Follow(p, tween.Progress(time.Second, tween.Linear))
+	f.Update(250 * time.Millisecond)
+	if !near(f.Position(), xmath.Vector2{X: 25}, 1e-6) || f.Angle() != 0 {
+		t.Errorf("unexpected position %v angle %f", f.Position(), f.Angle())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	p, err := Load(strings.NewReader(`{"type": "bezier", "points": [{"x": 0, "y": 0}, {"x": 0, "y": 100}, {"x": 100, "y": 100}, {"x": 100, "y": 0}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Shape.(*Bezier); !ok {
+		t.Errorf("expected a bezier but got %T", p.Shape)
+	}
+	if _, err := Load(strings.NewReader(`{"type": "bezier", "points": [{"x": 0, "y": 0}]}`)); err == nil {
+		t.Error("expected an error for a bezier without control points")
+	}
+}
+
+func TestLoadTiled(t *testing.T) {
+	const tiled = `{"layers": [
+		{"type": "tilelayer"},
+		{"type": "group", "layers": [{"type": "objectgroup", "objects": [
+			{"name": "patrol", "class": "catmullrom", "x": 10, "y": 20, "polyline": [{"x": 0, "y": 0}, {"x": 30, "y": 0}, {"x": 30, "y": 40}]},
+			{"name": "area", "x": 0, "y": 0, "polygon": [{"x": 0, "y": 0}, {"x": 10, "y": 0}, {"x": 10, "y": 10}]}
+		]}]}
+	]}`
+	p, err := LoadTiled(strings.NewReader(tiled), "patrol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Shape.(*CatmullRom); !ok || !near(p.At(0), xmath.Vector2{X: 10, Y: 20}, 1e-9) {
+		t.Errorf("expected a catmull-rom starting at {10 20} but got %T %v", p.Shape, p.At(0))
+	}
+	area, err := LoadTiled(strings.NewReader(tiled), "area")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(area.Length()-(20+math.Sqrt2*10)) > 0.5 {
+		t.Errorf("expected a closed polygon but got length %f", area.Length())
+	}
+	if _, err := LoadTiled(strings.NewReader(tiled), "missing"); err == nil {
+		t.Error("expected an error for a missing object")
+	}
+}
+
+func TestLoadTiledClass(t *testing.T) {
+	const tiled = `{"layers": [{"type": "objectgroup", "objects": [
+		{"name": "guard", "class": "patrol", "polyline": [{"x": 0, "y": 0}, {"x": 30, "y": 0}, {"x": 30, "y": 40}]},
+		{"name": "bird", "class": "patrol", "properties": [{"name": "spline", "type": "string", "value": "catmullrom"}],
+			"polyline": [{"x": 0, "y": 0}, {"x": 30, "y": 0}, {"x": 30, "y": 40}]},
+		{"name": "bad", "properties": [{"name": "spline", "type": "string", "value": "hermite"}],
+			"polyline": [{"x": 0, "y": 0}, {"x": 30, "y": 0}]}
+	]}]}`
+	guard, err := LoadTiled(strings.NewReader(tiled), "guard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := guard.Shape.(*Polyline); !ok {
+		t.Errorf("expected a game class to load as polyline but got %T", guard.Shape)
+	}
+	bird, err := LoadTiled(strings.NewReader(tiled), "bird")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bird.Shape.(*CatmullRom); !ok {
+		t.Errorf("expected the spline property to select a catmull-rom but got %T", bird.Shape)
+	}
+	if _, err := LoadTiled(strings.NewReader(tiled), "bad"); err == nil {
+		t.Error("expected an error for an unsupported spline property")
+	}
+}
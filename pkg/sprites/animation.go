@@ -10,6 +10,9 @@ type Animation struct {
 	Frames []Frame
 	Name   string
 	Loop   bool
+	// OnFrame is called with the index of every frame the animation enters,
+	// Reset enters frame 0.
+	OnFrame func(frame int)
 
 	frameIndex int
 	elapsed    time.Duration
@@ -19,9 +22,16 @@ func (a *Animation) Done() bool {
 	return !a.Loop && a.frameIndex == len(a.Frames)-1 && a.elapsed == 0
 }
 
+func (a *Animation) Frame() int {
+	return a.frameIndex
+}
+
 func (a *Animation) Reset() {
 	a.frameIndex = 0
 	a.elapsed = 0
+	if a.OnFrame != nil {
+		a.OnFrame(0)
+	}
 }
 
 func (a *Animation) Update(dt time.Duration) {
@@ -46,7 +56,18 @@ func (a *Animation) Update(dt time.Duration) {
 				return
 			}
 		}
+		if a.OnFrame != nil {
+			a.OnFrame(a.frameIndex)
+		}
+	}
+}
+
+// Normal returns the normal map of the current frame, nil if there is none.
+func (a *Animation) Normal() *ebiten.Image {
+	if len(a.Frames) == 0 {
+		return nil
 	}
+	return a.Frames[a.frameIndex].Normal
 }
 
 func (a *Animation) Image() *ebiten.Image {
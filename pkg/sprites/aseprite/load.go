@@ -26,7 +26,11 @@ func LoadResource(resource res.Resource) (*SpriteSheet, error) {
 		return nil, err
 	}
 	defer r.Close()
-	return Load(r)
+	sheet, err := Load(r)
+	if err != nil {
+		return nil, &res.DecodeError{Resource: resource, Format: "aseprite", Err: err}
+	}
+	return sheet, nil
 }
 
 func LoadFile(filename string) (*SpriteSheet, error) {
@@ -7,7 +7,9 @@ import (
 )
 
 type Frame struct {
-	Image    *ebiten.Image
+	Image *ebiten.Image
+	// Normal is the normal map of the frame for lighting, may be nil.
+	Normal   *ebiten.Image
 	Duration time.Duration
 	Width    int
 	Height   int
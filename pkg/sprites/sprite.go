@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
 )
 
 func NewSprite(sheet *SpriteSheet) *Sprite {
@@ -16,7 +17,11 @@ type Sprite struct {
 	sheet  *SpriteSheet
 	anim   *Animation
 	Shader Shader
-	geoM   ebiten.GeoM
+	// OnFrame is called with the animation name and frame index whenever
+	// the current animation enters a frame, including frame 0 when an
+	// animation is set.
+	OnFrame func(anim string, frame int)
+	geoM    ebiten.GeoM
 }
 
 func (s *Sprite) SpriteSheet() *SpriteSheet {
@@ -27,10 +32,18 @@ func (s *Sprite) Animation() *Animation {
 	return s.anim
 }
 
+// SetAnimation switches to the named animation, it keeps running when it
+// is already the current one.
 func (s *Sprite) SetAnimation(name string, loop bool) {
 	if s.anim == nil || name != s.anim.Name {
 		s.anim = s.sheet.Animation(name)
 		s.anim.Loop = loop
+		s.anim.OnFrame = func(frame int) {
+			if s.OnFrame != nil {
+				s.OnFrame(name, frame)
+			}
+		}
+		s.anim.OnFrame(s.anim.Frame())
 	}
 }
 
@@ -47,15 +60,7 @@ func (s *Sprite) Update(dt time.Duration) {
 func (s *Sprite) Draw(x float64, y float64, flipH bool, screen *ebiten.Image, colorScale ebiten.ColorScale) {
 	if s.anim != nil {
 		img := s.anim.Image()
-
-		s.geoM.Reset()
-		if flipH {
-			w := float64(img.Bounds().Dx())
-			s.geoM.Scale(-1, 1)
-			s.geoM.Translate(w, 0)
-		}
-
-		s.geoM.Translate(x, y)
+		s.place(img, x, y, flipH)
 
 		if s.Shader == nil {
 			screen.DrawImage(img, &ebiten.DrawImageOptions{
@@ -71,6 +76,34 @@ func (s *Sprite) Draw(x float64, y float64, flipH bool, screen *ebiten.Image, co
 	}
 }
 
+func (s *Sprite) place(img *ebiten.Image, x, y float64, flipH bool) {
+	s.geoM.Reset()
+	if flipH {
+		w := float64(img.Bounds().Dx())
+		s.geoM.Scale(-1, 1)
+		s.geoM.Translate(w, 0)
+	}
+	s.geoM.Translate(x, y)
+}
+
+// DrawNormal draws the normal map of the current frame like Draw, e.g. into
+// the normal buffer of the lighting. Flipped normals are mirrored too.
+func (s *Sprite) DrawNormal(x float64, y float64, flipH bool, dst *ebiten.Image) {
+	if s.anim == nil || s.anim.Normal() == nil {
+		return
+	}
+	img := s.anim.Normal()
+	s.place(img, x, y, flipH)
+	if !flipH {
+		dst.DrawImage(img, &ebiten.DrawImageOptions{GeoM: s.geoM})
+		return
+	}
+	var cm colorm.ColorM
+	cm.Scale(-1, 1, 1, 1)
+	cm.Translate(1, 0, 0, 0)
+	colorm.DrawImage(dst, img, cm, &colorm.DrawImageOptions{GeoM: s.geoM})
+}
+
 type Shader interface {
 	Draw(srcImage *ebiten.Image, screen *ebiten.Image, op *ebiten.DrawRectShaderOptions)
 	Update(dt time.Duration)
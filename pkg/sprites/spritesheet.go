@@ -1,5 +1,7 @@
 package sprites
 
+import "fmt"
+
 type Tag struct {
 	Name string
 	From int
@@ -29,6 +31,18 @@ func (s *SpriteSheet) Add(tag string, frames []Frame) {
 	s.Tags = append(s.Tags, t)
 }
 
+// SetNormals uses the frames of normals, a sheet with the same layout, as
+// normal maps.
+func (s *SpriteSheet) SetNormals(normals *SpriteSheet) error {
+	if len(normals.Frames) != len(s.Frames) {
+		return fmt.Errorf("normal map sheet has %d frames but expected %d", len(normals.Frames), len(s.Frames))
+	}
+	for idx := range s.Frames {
+		s.Frames[idx].Normal = normals.Frames[idx].Image
+	}
+	return nil
+}
+
 func (s *SpriteSheet) TagByName(tag string) *Tag {
 	for _, t := range s.Tags {
 		if t.Name == tag {
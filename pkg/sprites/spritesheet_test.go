@@ -1,6 +1,8 @@
 package sprites
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -78,3 +80,56 @@ func TestAnimLoop(t *testing.T) {
 	anim.Reset()
 
 }
+
+func TestAnimOnFrame(t *testing.T) {
+	s := &SpriteSheet{}
+	s.Add("run", []Frame{
+		{Duration: time.Millisecond * 10},
+		{Duration: time.Millisecond * 10},
+		{Duration: time.Millisecond * 10},
+	})
+
+	sprite := NewSprite(s)
+	entered := []int{}
+	sprite.OnFrame = func(anim string, frame int) {
+		if anim != "run" {
+			t.Errorf("expected animation run but got %s", anim)
+		}
+		entered = append(entered, frame)
+	}
+	sprite.SetAnimation("run", true)
+	sprite.Update(time.Millisecond * 25)
+	sprite.Update(time.Millisecond * 10)
+
+	expected := []int{0, 1, 2, 0}
+	if len(entered) != len(expected) {
+		t.Fatalf("expected frames %v but got %v", expected, entered)
+	}
+	for idx := range expected {
+		if entered[idx] != expected[idx] {
+			t.Errorf("expected frames %v but got %v", expected, entered)
+		}
+	}
+}
+
+func TestAnimOnFrameSwitch(t *testing.T) {
+	s := &SpriteSheet{}
+	s.Add("idle", []Frame{{Duration: time.Millisecond * 10}})
+	s.Add("run", []Frame{{Duration: time.Millisecond * 10}, {Duration: time.Millisecond * 10}})
+
+	sprite := NewSprite(s)
+	entered := []string{}
+	sprite.OnFrame = func(anim string, frame int) {
+		entered = append(entered, fmt.Sprintf("%s:%d", anim, frame))
+	}
+	sprite.SetAnimation("idle", true)
+	sprite.SetAnimation("idle", true)
+	sprite.SetAnimation("run", false)
+	sprite.Update(time.Millisecond * 10)
+	sprite.Animation().Reset()
+
+	expected := "idle:0 run:0 run:1 run:0"
+	if got := strings.Join(entered, " "); got != expected {
+		t.Errorf("expected frames %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,111 @@
+package ticker
+
+import "time"
+
+// NewClock creates a root clock reading t once per Tick.
+func NewClock(t Ticker) *Clock {
+	return &Clock{
+		source: t,
+		Scale:  1,
+	}
+}
+
+// Clock is a Ticker with time scale and pause. Child clocks advance with
+// the scaled time of their parent, so giving gameplay, ui and effects their
+// own clock allows bullet-time, hit-stop and pause menus:
+//
+//	root := ticker.NewClock(ticker.TPS())
+//	game := root.Child()
+//	ui := root.Child()
+//	game.Pause() // ui keeps running
+//
+// A clock must be ticked once per frame after its parent.
+type Clock struct {
+	source  Ticker
+	parent  *Clock
+	Scale   float64
+	paused  bool
+	step    bool
+	freeze  time.Duration
+	last    time.Duration
+	elapsed time.Duration
+}
+
+// Child creates a clock inheriting the scaling and pause of c.
+func (c *Clock) Child() *Clock {
+	return &Clock{
+		parent: c,
+		Scale:  1,
+	}
+}
+
+func (c *Clock) Tick() time.Duration {
+	var dt time.Duration
+	if c.parent != nil {
+		dt = c.parent.last
+	} else {
+		dt = c.source.Tick()
+	}
+
+	if c.freeze > 0 {
+		c.freeze -= dt
+		c.last = 0
+		if c.freeze < 0 {
+			// the frozen part of the frame is consumed, the rest advances
+			c.last = time.Duration(float64(-c.freeze) * c.Scale)
+			c.freeze = 0
+		}
+	} else if c.paused && !c.step {
+		c.last = 0
+	} else {
+		c.last = time.Duration(float64(dt) * c.Scale)
+	}
+	c.step = false
+	c.elapsed += c.last
+	return c.last
+}
+
+func (c *Clock) Pause() {
+	c.paused = true
+}
+
+func (c *Clock) Resume() {
+	c.paused = false
+}
+
+func (c *Clock) Paused() bool {
+	return c.paused
+}
+
+// StepFrame lets a paused clock advance for the next Tick only.
+func (c *Clock) StepFrame() {
+	c.step = true
+}
+
+// Freeze stops the clock for d of its parent's time, e.g. for hit-stop.
+func (c *Clock) Freeze(d time.Duration) {
+	c.freeze = d
+}
+
+// Last returns the duration returned by the last Tick.
+func (c *Clock) Last() time.Duration {
+	return c.last
+}
+
+// Elapsed returns the total scaled time of the clock.
+func (c *Clock) Elapsed() time.Duration {
+	return c.elapsed
+}
+
+// EffectiveScale is the product of the scales up to the root clock, zero
+// if the clock or one of its parents is paused.
+func (c *Clock) EffectiveScale() float64 {
+	scale := 1.0
+	for p := c; p != nil; p = p.parent {
+		if p.paused {
+			return 0
+		}
+		scale *= p.Scale
+	}
+	return scale
+}
@@ -0,0 +1,64 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+)
+
+type fixed time.Duration
+
+func (f fixed) Tick() time.Duration {
+	return time.Duration(f)
+}
+
+func TestClockHierarchy(t *testing.T) {
+	root := NewClock(fixed(10 * time.Millisecond))
+	game := root.Child()
+	effects := game.Child()
+	ui := root.Child()
+
+	tick := func() (time.Duration, time.Duration, time.Duration) {
+		root.Tick()
+		return game.Tick(), effects.Tick(), ui.Tick()
+	}
+
+	game.Scale = 0.5
+	effects.Scale = 2
+	g, e, u := tick()
+	if g != 5*time.Millisecond || e != 10*time.Millisecond || u != 10*time.Millisecond {
+		t.Errorf("unexpected scaled durations game=%s effects=%s ui=%s", g, e, u)
+	}
+	if s := effects.EffectiveScale(); s != 1 {
+		t.Errorf("expected effective scale 1 but got %f", s)
+	}
+
+	game.Pause()
+	g, e, u = tick()
+	if g != 0 || e != 0 || u != 10*time.Millisecond {
+		t.Errorf("pausing game must pause effects only: game=%s effects=%s ui=%s", g, e, u)
+	}
+
+	game.StepFrame()
+	g, e, _ = tick()
+	if g != 5*time.Millisecond || e != 10*time.Millisecond {
+		t.Errorf("expected a single stepped frame: game=%s effects=%s", g, e)
+	}
+	if g, _, _ = tick(); g != 0 {
+		t.Errorf("expected the clock paused again but got %s", g)
+	}
+	if game.Elapsed() != 10*time.Millisecond {
+		t.Errorf("expected 10ms elapsed but got %s", game.Elapsed())
+	}
+}
+
+func TestClockFreeze(t *testing.T) {
+	root := NewClock(fixed(10 * time.Millisecond))
+	root.Freeze(25 * time.Millisecond)
+
+	expected := []time.Duration{0, 0, 5 * time.Millisecond, 10 * time.Millisecond}
+	for idx, e := range expected {
+		if dt := root.Tick(); dt != e {
+			t.Errorf("tick %d: expected %s but got %s", idx, e, dt)
+		}
+	}
+}
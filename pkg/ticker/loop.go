@@ -0,0 +1,83 @@
+package ticker
+
+import "time"
+
+// NewLoop creates a loop running fixed steps of size step from the
+// durations reported by t.
+func NewLoop(t Ticker, step time.Duration) *Loop {
+	return &Loop{
+		ticker:   t,
+		Step:     step,
+		MaxSteps: 5,
+	}
+}
+
+// Loop accumulates ticker durations into fixed simulation steps. Physics,
+// tweens and timers updated from the steps behave the same regardless of
+// the frame rate.
+//
+// Ticking the loop from Draw with a Time ticker decouples the render rate
+// from the simulation rate, Alpha then interpolates between the last two
+// simulation states.
+type Loop struct {
+	ticker Ticker
+	Step   time.Duration
+	// MaxSteps limits the steps run per Tick, the remaining time is dropped
+	// so a slow frame can't trigger a spiral of death. Zero means no limit.
+	MaxSteps int
+	acc      time.Duration
+	dropped  time.Duration
+}
+
+// Tick reads the ticker once and calls update for every full step. It
+// returns the number of steps run.
+func (l *Loop) Tick(update func(dt time.Duration)) int {
+	return l.Advance(l.ticker.Tick(), update)
+}
+
+// Advance is like Tick but uses dt instead of reading the ticker.
+func (l *Loop) Advance(dt time.Duration, update func(dt time.Duration)) int {
+	if l.Step <= 0 {
+		return 0
+	}
+	l.acc += dt
+	if l.MaxSteps > 0 {
+		if max := l.Step * time.Duration(l.MaxSteps); l.acc > max {
+			// keep the fraction so Alpha stays continuous
+			excess := (l.acc - max) / l.Step * l.Step
+			l.dropped += excess
+			l.acc -= excess
+		}
+	}
+	steps := 0
+	for l.acc >= l.Step {
+		l.acc -= l.Step
+		update(l.Step)
+		steps++
+	}
+	return steps
+}
+
+// Alpha returns how far the loop is into the next step in the range [0, 1).
+func (l *Loop) Alpha() float64 {
+	if l.Step <= 0 {
+		return 0
+	}
+	return float64(l.acc) / float64(l.Step)
+}
+
+// Dropped returns the total time discarded by the MaxSteps clamp.
+func (l *Loop) Dropped() time.Duration {
+	return l.dropped
+}
+
+func (l *Loop) Reset() {
+	l.acc = 0
+	l.dropped = 0
+}
+
+// Interpolate blends the previous and current simulation value by alpha.
+// Example: x := Interpolate(prevX, curX, loop.Alpha())
+func Interpolate(prev, cur, alpha float64) float64 {
+	return prev + (cur-prev)*alpha
+}
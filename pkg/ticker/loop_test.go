@@ -0,0 +1,65 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+)
+
+type durations []time.Duration
+
+func (d *durations) Tick() time.Duration {
+	dt := (*d)[0]
+	*d = (*d)[1:]
+	return dt
+}
+
+func TestLoopFixedSteps(t *testing.T) {
+	frames := durations{25 * time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond}
+	loop := NewLoop(&frames, 10*time.Millisecond)
+
+	total := time.Duration(0)
+	update := func(dt time.Duration) {
+		if dt != 10*time.Millisecond {
+			t.Errorf("expected fixed step 10ms but got %s", dt)
+		}
+		total += dt
+	}
+
+	if steps := loop.Tick(update); steps != 2 {
+		t.Errorf("expected 2 steps but got %d", steps)
+	}
+	if a := loop.Alpha(); a < 0.49 || a > 0.51 {
+		t.Errorf("expected alpha 0.5 but got %f", a)
+	}
+	if steps := loop.Tick(update); steps != 1 {
+		t.Errorf("expected 1 step but got %d", steps)
+	}
+	if steps := loop.Tick(update); steps != 1 {
+		t.Errorf("expected 1 step but got %d", steps)
+	}
+	if total != 40*time.Millisecond {
+		t.Errorf("expected 40ms simulated but got %s", total)
+	}
+}
+
+func TestLoopClamp(t *testing.T) {
+	loop := NewLoop(nil, 10*time.Millisecond)
+	loop.MaxSteps = 3
+
+	steps := loop.Advance(time.Second+5*time.Millisecond, func(dt time.Duration) {})
+	if steps != 3 {
+		t.Errorf("expected clamp to 3 steps but got %d", steps)
+	}
+	if loop.Dropped() != 970*time.Millisecond {
+		t.Errorf("expected 970ms dropped but got %s", loop.Dropped())
+	}
+	if a := loop.Alpha(); a < 0.49 || a > 0.51 {
+		t.Errorf("expected alpha 0.5 after clamp but got %f", a)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	if v := Interpolate(10, 20, 0.25); v != 12.5 {
+		t.Errorf("expected 12.5 but got %f", v)
+	}
+}
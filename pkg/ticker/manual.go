@@ -0,0 +1,44 @@
+package ticker
+
+import "time"
+
+// Manual returns a deterministic ticker for tests and headless runs, every
+// Tick returns step unless durations were queued.
+func Manual(step time.Duration) *ManualTicker {
+	return &ManualTicker{
+		Step: step,
+	}
+}
+
+type ManualTicker struct {
+	Step    time.Duration
+	queue   []time.Duration
+	ticks   int
+	elapsed time.Duration
+}
+
+// Queue makes the next ticks return dts in order before falling back to Step.
+func (m *ManualTicker) Queue(dts ...time.Duration) {
+	m.queue = append(m.queue, dts...)
+}
+
+func (m *ManualTicker) Tick() time.Duration {
+	dt := m.Step
+	if len(m.queue) > 0 {
+		dt = m.queue[0]
+		m.queue = m.queue[1:]
+	}
+	m.ticks++
+	m.elapsed += dt
+	return dt
+}
+
+// Ticks returns the number of ticks so far.
+func (m *ManualTicker) Ticks() int {
+	return m.ticks
+}
+
+// Elapsed returns the sum of all returned durations.
+func (m *ManualTicker) Elapsed() time.Duration {
+	return m.elapsed
+}
@@ -13,7 +13,15 @@ func TPS() *TPSTicker {
 type TPSTicker struct {
 }
 
+// Tick returns the duration of one tick, with ebiten.SyncWithFPS the
+// duration of one frame at the current frame rate.
 func (d *TPSTicker) Tick() time.Duration {
-	dt := float64(1) / float64(ebiten.TPS()) * 1000
-	return time.Duration(dt) * time.Millisecond
+	tps := float64(ebiten.TPS())
+	if tps <= 0 {
+		tps = ebiten.ActualFPS()
+	}
+	if tps <= 0 {
+		tps = ebiten.DefaultTPS
+	}
+	return time.Duration(float64(time.Second) / tps)
 }
@@ -0,0 +1,184 @@
+package timer
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// NewCooldown returns a cooldown that is ready.
+func NewCooldown(d time.Duration) *Cooldown {
+	return &Cooldown{
+		Duration: d,
+	}
+}
+
+// Cooldown blocks Trigger for Duration after each successful trigger.
+type Cooldown struct {
+	Duration  time.Duration
+	OnTrigger func()
+	OnReady   func()
+	remaining time.Duration
+}
+
+// Trigger starts the cooldown and returns true if it was ready.
+func (c *Cooldown) Trigger() bool {
+	if !c.Ready() {
+		return false
+	}
+	c.remaining = c.Duration
+	if c.OnTrigger != nil {
+		c.OnTrigger()
+	}
+	return true
+}
+
+func (c *Cooldown) Ready() bool {
+	return c.remaining <= 0
+}
+
+// Reset makes the cooldown ready without calling OnReady.
+func (c *Cooldown) Reset() {
+	c.remaining = 0
+}
+
+func (c *Cooldown) Remaining() time.Duration {
+	return c.remaining
+}
+
+// Progress returns 0 right after a trigger up to 1 when ready, e.g. to fill
+// a radial icon.
+func (c *Cooldown) Progress() float64 {
+	if c.Duration <= 0 || c.remaining <= 0 {
+		return 1
+	}
+	return 1 - float64(c.remaining)/float64(c.Duration)
+}
+
+func (c *Cooldown) Update(dt time.Duration) {
+	if c.remaining <= 0 {
+		return
+	}
+	c.remaining -= dt
+	if c.remaining <= 0 {
+		c.remaining = 0
+		if c.OnReady != nil {
+			c.OnReady()
+		}
+	}
+}
+
+// NewCharges returns a full charge timer with max charges, one charge is
+// restored every recharge.
+func NewCharges(recharge time.Duration, max int) *Charges {
+	return &Charges{
+		Recharge: recharge,
+		Max:      max,
+		count:    max,
+	}
+}
+
+// Charges allows up to Max triggers in a row. Missing charges recharge one
+// after another, time left over from a restored charge counts towards the
+// next one.
+type Charges struct {
+	Recharge time.Duration
+	Max      int
+	OnCharge func(count int)
+	OnEmpty  func()
+	count    int
+	elapsed  time.Duration
+}
+
+// Trigger uses a charge and returns true if one was available.
+func (c *Charges) Trigger() bool {
+	if c.count <= 0 {
+		return false
+	}
+	c.count--
+	if c.count == 0 && c.OnEmpty != nil {
+		c.OnEmpty()
+	}
+	return true
+}
+
+func (c *Charges) Count() int {
+	return c.count
+}
+
+func (c *Charges) Ready() bool {
+	return c.count > 0
+}
+
+func (c *Charges) Full() bool {
+	return c.count >= c.Max
+}
+
+// Fill restores all charges.
+func (c *Charges) Fill() {
+	c.count = c.Max
+	c.elapsed = 0
+}
+
+// Remaining returns the time until the next charge.
+func (c *Charges) Remaining() time.Duration {
+	if c.Full() {
+		return 0
+	}
+	return c.Recharge - c.elapsed
+}
+
+// RemainingFull returns the time until all charges are restored.
+func (c *Charges) RemainingFull() time.Duration {
+	if c.Full() {
+		return 0
+	}
+	return c.Remaining() + time.Duration(c.Max-c.count-1)*c.Recharge
+}
+
+// Progress returns the recharge progress of the next charge, 1 when full.
+func (c *Charges) Progress() float64 {
+	if c.Full() || c.Recharge <= 0 {
+		return 1
+	}
+	return float64(c.elapsed) / float64(c.Recharge)
+}
+
+func (c *Charges) Update(dt time.Duration) {
+	if c.Full() {
+		c.elapsed = 0
+		return
+	}
+	c.elapsed += dt
+	for !c.Full() && c.elapsed >= c.Recharge {
+		c.elapsed -= c.Recharge
+		c.count++
+		if c.OnCharge != nil {
+			c.OnCharge(c.count)
+		}
+	}
+	if c.Full() {
+		c.elapsed = 0
+	}
+}
+
+// FormatRemaining formats a remaining time for cooldown labels: tenths below
+// ten seconds ("4.2"), whole seconds below a minute ("42") and "1:05" or
+// "1:02:03" above. Whole seconds are rounded up so the label never shows 0
+// while waiting.
+func FormatRemaining(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	if tenths := math.Ceil(d.Seconds() * 10); tenths < 100 {
+		return fmt.Sprintf("%.1f", tenths/10)
+	}
+	secs := int(math.Ceil(d.Seconds()))
+	switch {
+	case secs < 60:
+		return fmt.Sprintf("%d", secs)
+	case secs < 3600:
+		return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+	}
+	return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
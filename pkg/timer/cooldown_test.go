@@ -0,0 +1,81 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCooldown(t *testing.T) {
+	ready := 0
+	c := NewCooldown(100 * ms)
+	c.OnReady = func() { ready++ }
+
+	if !c.Trigger() || c.Trigger() {
+		t.Fatal("expected only the first trigger to succeed")
+	}
+	c.Update(25 * ms)
+	if c.Progress() != 0.25 || c.Remaining() != 75*ms {
+		t.Errorf("expected progress 0.25 but got %f remaining %s", c.Progress(), c.Remaining())
+	}
+	c.Update(100 * ms)
+	if !c.Ready() || ready != 1 || c.Progress() != 1 {
+		t.Errorf("expected the cooldown to be ready once but got ready=%d", ready)
+	}
+	c.Update(100 * ms)
+	if ready != 1 {
+		t.Error("OnReady must only fire when the cooldown ends")
+	}
+}
+
+func TestCharges(t *testing.T) {
+	charged := []int{}
+	c := NewCharges(100*ms, 3)
+	c.OnCharge = func(n int) { charged = append(charged, n) }
+
+	for range 3 {
+		if !c.Trigger() {
+			t.Fatal("expected 3 charges")
+		}
+	}
+	if c.Trigger() {
+		t.Fatal("expected no charge left")
+	}
+	if c.RemainingFull() != 300*ms {
+		t.Errorf("expected 300ms until full but got %s", c.RemainingFull())
+	}
+	c.Update(250 * ms)
+	if c.Count() != 2 || c.Remaining() != 50*ms || c.Progress() != 0.5 {
+		t.Errorf("expected 2 charges and 50ms remaining but got %d %s", c.Count(), c.Remaining())
+	}
+	c.Update(time.Second)
+	if !c.Full() || len(charged) != 3 || c.Progress() != 1 {
+		t.Errorf("expected full charges but got %d calls %v", c.Count(), charged)
+	}
+}
+
+func TestFormatRemaining(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		0:                "",
+		4210 * ms:        "4.3",
+		9990 * ms:        "10",
+		41500 * ms:       "42",
+		65 * time.Second: "1:05",
+		time.Hour + 2*time.Minute + 3*time.Second: "1:02:03",
+	} {
+		if got := FormatRemaining(d); got != expected {
+			t.Errorf("FormatRemaining(%s): expected %q but got %q", d, expected, got)
+		}
+	}
+}
+
+func TestTimerProgress(t *testing.T) {
+	tm := New(200 * ms)
+	tm.Update(50 * ms)
+	if tm.Progress() != 0.25 || tm.Remaining() != 150*ms {
+		t.Errorf("expected 0.25 progress but got %f", tm.Progress())
+	}
+	tm.Update(time.Second)
+	if tm.Progress() != 1 || tm.Remaining() != 0 {
+		t.Error("expected a finished timer")
+	}
+}
@@ -0,0 +1,261 @@
+package timer
+
+import (
+	"time"
+)
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Scheduler runs callbacks after a delay or repeatedly. Callbacks fire in
+// the order of their due time, when dt spans several intervals a repeating
+// task fires once per elapsed interval. Tasks scheduled from a callback
+// fire from the next Update on.
+type Scheduler struct {
+	now      time.Duration
+	end      time.Duration
+	updates  int
+	tasks    []*Task
+	order    int
+	paused   bool
+	updating bool
+}
+
+// Task is the handle of a scheduled callback.
+type Task struct {
+	s         *Scheduler
+	fn        func()
+	delay     time.Duration
+	interval  time.Duration
+	times     int
+	fired     int
+	due       time.Duration
+	remaining time.Duration
+	order     int
+	added     int
+	lastFired int
+	started   bool
+	paused    bool
+	done      bool
+	completed bool
+	next      []*Task
+}
+
+// After calls fn once after d.
+func (s *Scheduler) After(d time.Duration, fn func()) *Task {
+	return s.start(s.task(d, d, 1, fn))
+}
+
+// Every calls fn every d until cancelled. With d <= 0 fn is called once per
+// Update.
+func (s *Scheduler) Every(d time.Duration, fn func()) *Task {
+	return s.start(s.task(d, d, -1, fn))
+}
+
+// Repeat calls fn every d for n times.
+func (s *Scheduler) Repeat(d time.Duration, n int, fn func()) *Task {
+	return s.start(s.task(d, d, n, fn))
+}
+
+func (s *Scheduler) task(delay, interval time.Duration, times int, fn func()) *Task {
+	s.order++
+	added := 0
+	if s.updating {
+		added = s.updates
+	}
+	return &Task{
+		s:         s,
+		fn:        fn,
+		delay:     delay,
+		interval:  interval,
+		times:     times,
+		order:     s.order,
+		added:     added,
+		lastFired: -1,
+	}
+}
+
+func (s *Scheduler) start(t *Task) *Task {
+	if t.times == 0 {
+		t.done = true
+		t.completed = true
+		return t
+	}
+	t.started = true
+	t.due = s.now + t.delay
+	s.tasks = append(s.tasks, t)
+	return t
+}
+
+// Then calls fn once, d after t has fired for the last time. Cancelling t
+// cancels the chain.
+func (t *Task) Then(d time.Duration, fn func()) *Task {
+	next := t.s.task(d, d, 1, fn)
+	if t.completed {
+		return t.s.start(next)
+	}
+	if t.done {
+		next.done = true
+		return next
+	}
+	t.next = append(t.next, next)
+	return next
+}
+
+// Cancel stops the task and all tasks chained to it.
+func (t *Task) Cancel() {
+	t.done = true
+	for _, n := range t.next {
+		n.Cancel()
+	}
+	t.next = nil
+}
+
+// Pause holds the task, a chained task paused before it starts keeps its
+// full delay and counts it down once resumed.
+func (t *Task) Pause() {
+	if t.paused || t.done {
+		return
+	}
+	t.paused = true
+	if t.started {
+		t.remaining = t.due - t.s.now
+	} else {
+		t.remaining = t.delay
+	}
+}
+
+func (t *Task) Resume() {
+	if !t.paused {
+		return
+	}
+	t.paused = false
+	t.due = t.s.now + t.remaining
+}
+
+func (t *Task) Paused() bool {
+	return t.paused
+}
+
+// Done reports whether the task fired for the last time or was cancelled.
+func (t *Task) Done() bool {
+	return t.done
+}
+
+// Fired returns how often the callback was called.
+func (t *Task) Fired() int {
+	return t.fired
+}
+
+// Remaining returns the time until the next call, for chained tasks not
+// started yet the delay after the previous task finishes.
+func (t *Task) Remaining() time.Duration {
+	switch {
+	case t.done:
+		return 0
+	case !t.started:
+		return t.delay
+	case t.paused:
+		return t.remaining
+	}
+	return max(t.due-t.s.now, 0)
+}
+
+func (t *Task) fire() {
+	t.fired++
+	t.lastFired = t.s.updates
+	t.fn()
+	if t.done {
+		return
+	}
+	if t.times > 0 && t.fired >= t.times {
+		t.done = true
+		t.completed = true
+		for _, n := range t.next {
+			t.s.start(n)
+		}
+		t.next = nil
+		return
+	}
+	if t.interval > 0 {
+		t.due += t.interval
+	} else {
+		t.due = t.s.end
+	}
+}
+
+func (s *Scheduler) Pause() {
+	s.paused = true
+}
+
+func (s *Scheduler) Resume() {
+	s.paused = false
+}
+
+func (s *Scheduler) Paused() bool {
+	return s.paused
+}
+
+// Len returns the number of started tasks that are not done.
+func (s *Scheduler) Len() int {
+	n := 0
+	for _, t := range s.tasks {
+		if !t.done {
+			n++
+		}
+	}
+	return n
+}
+
+// Clear cancels all tasks.
+func (s *Scheduler) Clear() {
+	for _, t := range s.tasks {
+		t.Cancel()
+	}
+	s.tasks = nil
+}
+
+func (s *Scheduler) Update(dt time.Duration) {
+	if s.paused {
+		return
+	}
+	s.updates++
+	s.end = s.now + dt
+	s.updating = true
+	for {
+		t := s.nextDue()
+		if t == nil {
+			break
+		}
+		s.now = max(t.due, s.now)
+		t.fire()
+	}
+	s.updating = false
+	s.now = s.end
+
+	active := s.tasks[:0]
+	for _, t := range s.tasks {
+		if !t.done {
+			active = append(active, t)
+		}
+	}
+	clear(s.tasks[len(active):])
+	s.tasks = active
+}
+
+func (s *Scheduler) nextDue() *Task {
+	var next *Task
+	for _, t := range s.tasks {
+		if t.done || t.paused || t.due > s.end || t.added == s.updates {
+			continue
+		}
+		if t.interval <= 0 && t.lastFired == s.updates {
+			continue
+		}
+		if next == nil || t.due < next.due || t.due == next.due && t.order < next.order {
+			next = t
+		}
+	}
+	return next
+}
@@ -0,0 +1,142 @@
+package timer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+const ms = time.Millisecond
+
+func TestSchedulerAfter(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	task := s.After(100*ms, func() { fired++ })
+
+	s.Update(60 * ms)
+	if fired != 0 || task.Remaining() != 40*ms {
+		t.Errorf("expected 40ms remaining but got fired=%d remaining=%s", fired, task.Remaining())
+	}
+	s.Update(60 * ms)
+	s.Update(60 * ms)
+	if fired != 1 || !task.Done() {
+		t.Errorf("expected a single call but got %d", fired)
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected finished tasks to be removed but %d left", s.Len())
+	}
+}
+
+func TestSchedulerRescheduleInCallback(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	var again func()
+	again = func() {
+		fired++
+		s.After(0, again)
+	}
+	s.After(0, again)
+
+	s.Update(10 * ms)
+	if fired != 1 {
+		t.Errorf("expected a single call per update but got %d", fired)
+	}
+	s.Update(10 * ms)
+	if fired != 2 {
+		t.Errorf("expected the rescheduled task to fire on the next update but got %d calls", fired)
+	}
+}
+
+func TestSchedulerMultipleFirings(t *testing.T) {
+	s := NewScheduler()
+	order := []string{}
+	s.Every(30*ms, func() { order = append(order, "a") })
+	s.Every(50*ms, func() { order = append(order, "b") })
+
+	s.Update(100 * ms)
+	expected := []string{"a", "b", "a", "a", "b"}
+	if !slices.Equal(order, expected) {
+		t.Errorf("expected firings %v but got %v", expected, order)
+	}
+}
+
+func TestSchedulerRepeatAndThen(t *testing.T) {
+	s := NewScheduler()
+	calls := []time.Duration{}
+	now := time.Duration(0)
+	record := func() { calls = append(calls, now) }
+
+	s.Repeat(20*ms, 3, record).Then(50*ms, record)
+	for range 20 {
+		now += 10 * ms
+		s.Update(10 * ms)
+	}
+	expected := []time.Duration{20 * ms, 40 * ms, 60 * ms, 110 * ms}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("expected calls at %v but got %v", expected, calls)
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	first := s.After(10*ms, func() { fired++ })
+	first.Then(10*ms, func() { fired++ })
+	first.Cancel()
+
+	var every *Task
+	every = s.Every(10*ms, func() {
+		fired++
+		if every.Fired() == 2 {
+			every.Cancel()
+		}
+	})
+	s.Update(100 * ms)
+	if fired != 2 {
+		t.Errorf("expected 2 calls from every but got %d", fired)
+	}
+}
+
+func TestSchedulerPause(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	task := s.After(50*ms, func() { fired++ })
+
+	s.Update(20 * ms)
+	task.Pause()
+	s.Update(100 * ms)
+	if fired != 0 || task.Remaining() != 30*ms {
+		t.Errorf("paused task must keep 30ms remaining but got %s", task.Remaining())
+	}
+	task.Resume()
+
+	s.Pause()
+	s.Update(100 * ms)
+	if fired != 0 {
+		t.Error("paused scheduler must not fire")
+	}
+	s.Resume()
+	s.Update(30 * ms)
+	if fired != 1 {
+		t.Error("expected the task to fire after resume")
+	}
+}
+
+func TestSchedulerPauseChained(t *testing.T) {
+	s := NewScheduler()
+	fired := 0
+	chained := s.After(20*ms, func() {}).Then(30*ms, func() { fired++ })
+	chained.Pause()
+
+	s.Update(20 * ms)
+	s.Update(100 * ms)
+	chained.Resume()
+	s.Update(20 * ms)
+	if fired != 0 || chained.Remaining() != 10*ms {
+		t.Errorf("resumed chained task must keep 10ms remaining but got fired=%d remaining=%s", fired, chained.Remaining())
+	}
+	s.Update(10 * ms)
+	if fired != 1 {
+		t.Error("expected the chained task to fire after its delay")
+	}
+}
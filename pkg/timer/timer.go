@@ -28,6 +28,19 @@ func (t *Timer) Done() bool {
 	return t.elapsed >= t.duration
 }
 
+// Remaining returns the time until the timer is done.
+func (t *Timer) Remaining() time.Duration {
+	return max(t.duration-t.elapsed, 0)
+}
+
+// Progress returns the elapsed ratio from 0 to 1.
+func (t *Timer) Progress() float64 {
+	if t.duration <= 0 {
+		return 1
+	}
+	return min(float64(t.elapsed)/float64(t.duration), 1)
+}
+
 func (t *Timer) Update(dt time.Duration) bool {
 	t.elapsed = t.elapsed + dt
 	return t.Done()
@@ -0,0 +1,38 @@
+package tween
+
+import "time"
+
+// Bind writes the value of u to target on every update.
+func Bind(u Updater, target *float64) *Bound {
+	return BindFunc(u, func(v float64) {
+		*target = v
+	})
+}
+
+// BindFunc passes the value of u to set on every update.
+func BindFunc(u Updater, set func(v float64)) *Bound {
+	return &Bound{
+		tween: u,
+		set:   set,
+	}
+}
+
+// Bound is an Updater applying its value to a target. OnComplete is called
+// when the tween reports done after it was running, for looping tweens once
+// per loop.
+type Bound struct {
+	OnComplete func()
+	tween      Updater
+	set        func(v float64)
+	done       bool
+}
+
+func (b *Bound) Update(dt time.Duration) (float64, bool) {
+	val, done := b.tween.Update(dt)
+	b.set(val)
+	if done && !b.done && b.OnComplete != nil {
+		b.OnComplete()
+	}
+	b.done = done
+	return val, done
+}
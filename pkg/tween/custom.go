@@ -0,0 +1,130 @@
+package tween
+
+import (
+	"math"
+	"slices"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+// CubicBezier returns an easing like the CSS cubic-bezier(x1, y1, x2, y2)
+// timing function. x1 and x2 are clamped to 0..1, y values may overshoot.
+//
+// Example:
+//
+//	ease := CubicBezier(0.25, 0.1, 0.25, 1) // CSS "ease"
+func CubicBezier(x1, y1, x2, y2 float64) TweenFunc {
+	x1 = xmath.Clamp(x1, 0, 1)
+	x2 = xmath.Clamp(x2, 0, 1)
+	bezier := func(s, p1, p2 float64) float64 {
+		inv := 1 - s
+		return 3*inv*inv*s*p1 + 3*inv*s*s*p2 + s*s*s
+	}
+	slope := func(s, p1, p2 float64) float64 {
+		inv := 1 - s
+		return 3*inv*inv*p1 + 6*inv*s*(p2-p1) + 3*s*s*(1-p2)
+	}
+	solve := func(x float64) float64 {
+		s := x
+		for range 8 {
+			err := bezier(s, x1, x2) - x
+			if math.Abs(err) < 1e-7 {
+				return s
+			}
+			d := slope(s, x1, x2)
+			if math.Abs(d) < 1e-6 {
+				break
+			}
+			s -= err / d
+		}
+		lo, hi := 0.0, 1.0
+		s = x
+		for range 50 {
+			err := bezier(s, x1, x2) - x
+			if math.Abs(err) < 1e-7 {
+				break
+			}
+			if err > 0 {
+				hi = s
+			} else {
+				lo = s
+			}
+			s = (lo + hi) / 2
+		}
+		return s
+	}
+	return func(t, b, c, d float64) float64 {
+		x := xmath.Clamp(t/d, 0, 1)
+		return c*bezier(solve(x), y1, y2) + b
+	}
+}
+
+// Steps returns an easing jumping in n equal steps like CSS steps(). With
+// start the first jump happens at the beginning (jump-start), otherwise at
+// the end of each interval (jump-end).
+func Steps(n int, start bool) TweenFunc {
+	n = max(n, 1)
+	return func(t, b, c, d float64) float64 {
+		x := xmath.Clamp(t/d, 0, 1)
+		step := math.Floor(x * float64(n))
+		if start && x > 0 {
+			step = math.Min(step+1, float64(n))
+		}
+		return c*step/float64(n) + b
+	}
+}
+
+// Curve returns an easing from keyframes, X is the time and Y the progress,
+// both usually from 0 to 1. Values between keys are interpolated linearly,
+// e.g. to match a curve exported from an animation tool.
+func Curve(keys ...xmath.Vector2) TweenFunc {
+	keys = slices.Clone(keys)
+	slices.SortStableFunc(keys, func(a, b xmath.Vector2) int {
+		switch {
+		case a.X < b.X:
+			return -1
+		case a.X > b.X:
+			return 1
+		}
+		return 0
+	})
+	return func(t, b, c, d float64) float64 {
+		return c*sampleCurve(keys, t/d) + b
+	}
+}
+
+// Samples returns a Curve from values spaced evenly over time.
+func Samples(values ...float64) TweenFunc {
+	keys := make([]xmath.Vector2, len(values))
+	for idx, v := range values {
+		x := 0.0
+		if len(values) > 1 {
+			x = float64(idx) / float64(len(values)-1)
+		}
+		keys[idx] = xmath.Vector2{X: x, Y: v}
+	}
+	return Curve(keys...)
+}
+
+func sampleCurve(keys []xmath.Vector2, x float64) float64 {
+	if len(keys) == 0 {
+		return x
+	}
+	idx, _ := slices.BinarySearchFunc(keys, x, func(k xmath.Vector2, x float64) int {
+		if k.X < x {
+			return -1
+		}
+		return 1
+	})
+	if idx == 0 {
+		return keys[0].Y
+	}
+	if idx == len(keys) {
+		return keys[len(keys)-1].Y
+	}
+	a, b := keys[idx-1], keys[idx]
+	if b.X == a.X {
+		return b.Y
+	}
+	return a.Y + (b.Y-a.Y)*(x-a.X)/(b.X-a.X)
+}
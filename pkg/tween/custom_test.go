@@ -0,0 +1,73 @@
+package tween
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+)
+
+func TestCubicBezier(t *testing.T) {
+	linear := CubicBezier(0, 0, 1, 1)
+	ease := CubicBezier(0.25, 0.1, 0.25, 1)
+	for _, x := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		if v := linear(x, 0, 1, 1); math.Abs(v-x) > 1e-6 {
+			t.Errorf("linear bezier at %f: got %f", x, v)
+		}
+	}
+	// reference values of the CSS "ease" curve
+	if v := ease(0.5, 0, 1, 1); math.Abs(v-0.8024) > 1e-3 {
+		t.Errorf("expected ease(0.5) to be 0.8024 but got %f", v)
+	}
+	if v := ease(1, 10, 20, 1); v != 30 {
+		t.Errorf("expected the end value but got %f", v)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	end, start := Steps(4, false), Steps(4, true)
+	if v := end(0.3, 0, 1, 1); v != 0.25 {
+		t.Errorf("expected jump-end 0.25 but got %f", v)
+	}
+	if v := start(0.3, 0, 1, 1); v != 0.5 {
+		t.Errorf("expected jump-start 0.5 but got %f", v)
+	}
+	if end(1, 0, 1, 1) != 1 || start(0, 0, 1, 1) != 0 {
+		t.Error("expected steps to start at 0 and end at 1")
+	}
+}
+
+func TestCurve(t *testing.T) {
+	curve := Curve(xmath.Vector2{X: 1, Y: 1}, xmath.Vector2{X: 0, Y: 0}, xmath.Vector2{X: 0.5, Y: 0.8})
+	if v := curve(0.25, 0, 10, 1); math.Abs(v-4) > 1e-9 {
+		t.Errorf("expected 4 but got %f", v)
+	}
+	if v := Samples(0, 1, 0)(0.75, 0, 1, 1); math.Abs(v-0.5) > 1e-9 {
+		t.Errorf("expected 0.5 but got %f", v)
+	}
+}
+
+func TestSpring(t *testing.T) {
+	s := NewSpring(0, 100)
+	for range 10 {
+		s.Update(16 * time.Millisecond)
+	}
+	v := s.Velocity()
+	if v <= 0 {
+		t.Fatalf("expected the spring to move towards the target but got velocity %f", v)
+	}
+	s.SetTarget(-100)
+	if s.Velocity() != v {
+		t.Error("retargeting must keep the velocity")
+	}
+	done := false
+	for range 1000 {
+		if _, done = s.Update(16 * time.Millisecond); done {
+			break
+		}
+	}
+	if !done || s.Value() != -100 {
+		t.Errorf("expected the spring to rest at -100 but got %f", s.Value())
+	}
+}
@@ -22,8 +22,104 @@ func (g *Group) Update(dt time.Duration) bool {
 	all := true
 	for _, t := range g.list {
 		val, done := t.tween.Update(dt)
-		t.val = &val
+		if t.val != nil {
+			*t.val = val
+		}
 		all = all && done
 	}
 	return all
 }
+
+// NewParallel runs all tweens at once, it is done when all of them are
+// done. The value is the one of the first tween.
+func NewParallel(tweens ...Updater) *Parallel {
+	return &Parallel{
+		tweens: tweens,
+		done:   make([]bool, len(tweens)),
+	}
+}
+
+type Parallel struct {
+	OnComplete func()
+	tweens     []Updater
+	done       []bool
+	val        float64
+	finished   bool
+}
+
+func (p *Parallel) Update(dt time.Duration) (float64, bool) {
+	if p.finished {
+		return p.val, true
+	}
+	all := true
+	for idx, t := range p.tweens {
+		if p.done[idx] {
+			continue
+		}
+		val, done := t.Update(dt)
+		if idx == 0 {
+			p.val = val
+		}
+		p.done[idx] = done
+		all = all && done
+	}
+	if all {
+		p.finished = true
+		if p.OnComplete != nil {
+			p.OnComplete()
+		}
+	}
+	return p.val, all
+}
+
+// NewStagger starts each tween delay after the previous one started, e.g.
+// to let menu entries slide in one after another.
+func NewStagger(delay time.Duration, tweens ...Updater) *Stagger {
+	return &Stagger{
+		Delay:  delay,
+		tweens: tweens,
+		done:   make([]bool, len(tweens)),
+	}
+}
+
+type Stagger struct {
+	Delay      time.Duration
+	OnComplete func()
+	tweens     []Updater
+	done       []bool
+	elapsed    time.Duration
+	val        float64
+	finished   bool
+}
+
+func (s *Stagger) Update(dt time.Duration) (float64, bool) {
+	if s.finished {
+		return s.val, true
+	}
+	prev := s.elapsed
+	s.elapsed += dt
+	all := true
+	for idx, t := range s.tweens {
+		if s.done[idx] {
+			continue
+		}
+		start := time.Duration(idx) * s.Delay
+		if s.elapsed < start {
+			all = false
+			continue
+		}
+		val, done := t.Update(s.elapsed - max(prev, start))
+		if idx == 0 {
+			s.val = val
+		}
+		s.done[idx] = done
+		all = all && done
+	}
+	if all {
+		s.finished = true
+		if s.OnComplete != nil {
+			s.OnComplete()
+		}
+	}
+	return s.val, all
+}
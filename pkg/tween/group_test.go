@@ -0,0 +1,107 @@
+package tween
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const ms = time.Millisecond
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestGroupWritesTargets(t *testing.T) {
+	var a, b float64
+	g := &Group{}
+	g.Add(New(0, 10, 100*ms, Linear), &a)
+	g.Add(New(0, 20, 100*ms, Linear), &b)
+	g.Update(50 * ms)
+	if a != 5 || b != 10 {
+		t.Errorf("expected bound values 5 and 10 but got %f %f", a, b)
+	}
+}
+
+func TestBindAndManager(t *testing.T) {
+	x, y := 0.0, 0.0
+	completed, removed := 0, 0
+	m := NewManager()
+	b, _ := m.To(&x, 100, 100*ms, Linear, func() { removed++ })
+	b.OnComplete = func() { completed++ }
+	m.Add(BindFunc(New(0, 1, 300*ms, Linear), func(v float64) { y = v }), nil)
+
+	m.Update(50 * ms)
+	if x != 50 || m.Len() != 2 {
+		t.Errorf("expected x=50 with 2 tweens but got %f %d", x, m.Len())
+	}
+	m.Update(60 * ms)
+	if x != 100 || completed != 1 || removed != 1 || m.Len() != 1 {
+		t.Errorf("expected the first tween to be removed but got x=%f completed=%d removed=%d len=%d", x, completed, removed, m.Len())
+	}
+	m.Update(time.Second)
+	if y != 1 || m.Len() != 0 {
+		t.Errorf("expected all tweens to be done but got y=%f len=%d", y, m.Len())
+	}
+}
+
+// updaterFunc is not comparable, Remove must not compare Updaters.
+type updaterFunc func(dt time.Duration) (float64, bool)
+
+func (f updaterFunc) Update(dt time.Duration) (float64, bool) {
+	return f(dt)
+}
+
+func TestManagerRemove(t *testing.T) {
+	m := NewManager()
+	calls := 0
+	u := updaterFunc(func(time.Duration) (float64, bool) {
+		calls++
+		return 0, false
+	})
+	id := m.Add(u, nil)
+	m.Add(u, nil)
+	m.Update(10 * ms)
+	m.Remove(id)
+	m.Update(10 * ms)
+	if calls != 3 || m.Len() != 1 {
+		t.Errorf("expected only the removed tween to stop but got calls=%d len=%d", calls, m.Len())
+	}
+}
+
+func TestParallel(t *testing.T) {
+	var a, b float64
+	completed := 0
+	p := NewParallel(Bind(New(0, 1, 100*ms, Linear), &a), Bind(New(0, 1, 200*ms, Linear), &b))
+	p.OnComplete = func() { completed++ }
+	if _, done := p.Update(150 * ms); done || a != 1 || !near(b, 0.75) {
+		t.Errorf("expected a=1 and b=0.75 but got %f %f", a, b)
+	}
+	p.Update(100 * ms)
+	p.Update(100 * ms)
+	if completed != 1 {
+		t.Errorf("expected a single completion but got %d", completed)
+	}
+}
+
+func TestStagger(t *testing.T) {
+	vals := make([]float64, 3)
+	s := NewStagger(50*ms,
+		Bind(New(0, 1, 100*ms, Linear), &vals[0]),
+		Bind(New(0, 1, 100*ms, Linear), &vals[1]),
+		Bind(New(0, 1, 100*ms, Linear), &vals[2]),
+	)
+	s.Update(30 * ms)
+	s.Update(45 * ms)
+	if !near(vals[0], 0.75) || !near(vals[1], 0.25) || vals[2] != 0 {
+		t.Errorf("unexpected staggered values %v", vals)
+	}
+	completed := false
+	s.OnComplete = func() { completed = true }
+	for range 10 {
+		s.Update(20 * ms)
+	}
+	if !completed || vals[2] != 1 {
+		t.Errorf("expected the stagger to complete but got %v", vals)
+	}
+}
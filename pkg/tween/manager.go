@@ -0,0 +1,89 @@
+package tween
+
+import "time"
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Manager updates tweens and removes them once they are done.
+type Manager struct {
+	list []managed
+	last ID
+}
+
+// ID identifies a tween added to a Manager.
+type ID uint64
+
+type managed struct {
+	id    ID
+	tween Updater
+	done  func()
+}
+
+// Add starts u, done is called after its last update and may be nil.
+func (m *Manager) Add(u Updater, done func()) ID {
+	m.last++
+	m.list = append(m.list, managed{
+		id:    m.last,
+		tween: u,
+		done:  done,
+	})
+	return m.last
+}
+
+// To binds a new tween from the current value of target to end and adds it.
+func (m *Manager) To(target *float64, end float64, duration time.Duration, easing TweenFunc, done func()) (*Bound, ID) {
+	b := Bind(New(*target, end, duration, easing), target)
+	return b, m.Add(b, done)
+}
+
+// Remove drops the tween added as id without calling its done callback.
+func (m *Manager) Remove(id ID) {
+	for idx := range m.list {
+		if m.list[idx].id == id {
+			m.list[idx].tween = nil
+		}
+	}
+}
+
+func (m *Manager) Len() int {
+	n := 0
+	for _, t := range m.list {
+		if t.tween != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Manager) Clear() {
+	m.list = nil
+}
+
+// Update steps all tweens, tweens added by callbacks start with the next
+// Update.
+func (m *Manager) Update(dt time.Duration) {
+	n := len(m.list)
+	for idx := 0; idx < n && idx < len(m.list); idx++ {
+		t := m.list[idx]
+		if t.tween == nil {
+			continue
+		}
+		if _, done := t.tween.Update(dt); !done {
+			continue
+		}
+		m.list[idx].tween = nil
+		if t.done != nil {
+			t.done()
+		}
+	}
+	active := m.list[:0]
+	for _, t := range m.list {
+		if t.tween != nil {
+			active = append(active, t)
+		}
+	}
+	clear(m.list[len(active):])
+	m.list = active
+}
@@ -0,0 +1,90 @@
+package tween
+
+import (
+	"slices"
+	"strings"
+)
+
+var easings = map[string]TweenFunc{}
+
+func init() {
+	for name, fn := range map[string]TweenFunc{
+		"Linear":       Linear,
+		"InQuad":       InQuad,
+		"OutQuad":      OutQuad,
+		"InOutQuad":    InOutQuad,
+		"OutInQuad":    OutInQuad,
+		"InCubic":      InCubic,
+		"OutCubic":     OutCubic,
+		"InOutCubic":   InOutCubic,
+		"OutInCubic":   OutInCubic,
+		"InQuart":      InQuart,
+		"OutQuart":     OutQuart,
+		"InOutQuart":   InOutQuart,
+		"OutInQuart":   OutInQuart,
+		"InQuint":      InQuint,
+		"OutQuint":     OutQuint,
+		"InOutQuint":   InOutQuint,
+		"OutInQuint":   OutInQuint,
+		"InSine":       InSine,
+		"OutSine":      OutSine,
+		"InOutSine":    InOutSine,
+		"OutInSine":    OutInSine,
+		"InExpo":       InExpo,
+		"OutExpo":      OutExpo,
+		"InOutExpo":    InOutExpo,
+		"OutInExpo":    OutInExpo,
+		"InCirc":       InCirc,
+		"OutCirc":      OutCirc,
+		"InOutCirc":    InOutCirc,
+		"OutInCirc":    OutInCirc,
+		"InElastic":    InElastic,
+		"OutElastic":   OutElastic,
+		"InOutElastic": InOutElastic,
+		"OutInElastic": OutInElastic,
+		"InBack":       InBack,
+		"OutBack":      OutBack,
+		"InOutBack":    InOutBack,
+		"OutInBack":    OutInBack,
+		"InBounce":     InBounce,
+		"OutBounce":    OutBounce,
+		"InOutBounce":  InOutBounce,
+		"OutInBounce":  OutInBounce,
+		"Step":         Steps(1, false),
+	} {
+		RegisterEasing(name, fn)
+	}
+}
+
+// RegisterEasing makes fn available by name, e.g. for easings in data
+// files. Names ignore case, "-", "_" and spaces.
+func RegisterEasing(name string, fn TweenFunc) {
+	easings[easingKey(name)] = fn
+}
+
+// Easing returns the easing registered as name, "outQuad", "OutQuad" and
+// "out-quad" are the same.
+func Easing(name string) (TweenFunc, bool) {
+	fn, ok := easings[easingKey(name)]
+	return fn, ok
+}
+
+// EasingNames returns the normalized names of all registered easings.
+func EasingNames() []string {
+	names := make([]string, 0, len(easings))
+	for name := range easings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func easingKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
@@ -0,0 +1,19 @@
+package tween
+
+import "testing"
+
+func TestEasingNames(t *testing.T) {
+	for _, name := range []string{"outQuad", "OutQuad", "out-quad", "out_quad"} {
+		fn, ok := Easing(name)
+		if !ok || fn(0.5, 0, 1, 1) != OutQuad(0.5, 0, 1, 1) {
+			t.Errorf("expected %q to resolve to OutQuad", name)
+		}
+	}
+	if _, ok := Easing("wobble"); ok {
+		t.Error("expected an unknown easing")
+	}
+	RegisterEasing("wobble", Steps(3, false))
+	if _, ok := Easing("Wobble"); !ok {
+		t.Error("expected a registered easing")
+	}
+}
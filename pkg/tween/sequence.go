@@ -30,21 +30,48 @@ func (s *Sequence) SetLoop(loop bool) {
 	s.loop = loop
 }
 
+// Update advances the current tween, time left over when a tween ends is
+// passed on to the next one.
 func (s *Sequence) Update(dt time.Duration) (float64, bool) {
 	last := len(s.tweens) - 1
+	if last < 0 {
+		return 0, true
+	}
 	for idx, t := range s.tweens {
 		if s.done[idx] {
 			continue
 		}
 		val, done := t.Update(dt)
-		if done {
-			s.done[idx] = true
+		if !done {
+			return val, false
+		}
+		s.done[idx] = true
+		dt = t.Overshoot()
+		if idx < last {
+			continue
+		}
+		if s.loop && dt > 0 {
+			if total := s.total(); total > 0 {
+				s.Reset()
+				val, _ = s.Update(dt % total)
+			}
 		}
-		return val, done && idx == last
+		return val, true
 	}
 	if s.loop {
 		s.Reset()
-		return s.tweens[0].Update(dt)
+		return s.Update(dt)
 	}
 	return s.tweens[last].Update(dt)
 }
+
+func (s *Sequence) total() time.Duration {
+	total := time.Duration(0)
+	for _, t := range s.tweens {
+		if t.Total() < 0 {
+			return -1
+		}
+		total += t.Total()
+	}
+	return total
+}
@@ -0,0 +1,86 @@
+package tween
+
+import (
+	"math"
+	"time"
+)
+
+const springStep = time.Millisecond
+
+// NewSpring returns a spring at rest at value moving towards target.
+func NewSpring(value, target float64) *Spring {
+	return &Spring{
+		Stiffness: 170,
+		Damping:   26,
+		Mass:      1,
+		Precision: 0.001,
+		value:     value,
+		target:    target,
+	}
+}
+
+// Spring is a damped spring driven by physics instead of a duration. It is
+// an Updater, done once it comes to rest at the target.
+type Spring struct {
+	Stiffness float64
+	Damping   float64
+	Mass      float64
+	// Precision is the distance and speed below which the spring rests.
+	Precision float64
+	value     float64
+	target    float64
+	velocity  float64
+}
+
+// SetTarget changes the target and keeps the current velocity, so a spring
+// retargeted in motion does not jump.
+func (s *Spring) SetTarget(target float64) {
+	s.target = target
+}
+
+func (s *Spring) Target() float64 {
+	return s.target
+}
+
+func (s *Spring) Value() float64 {
+	return s.value
+}
+
+// Velocity returns the speed in units per second.
+func (s *Spring) Velocity() float64 {
+	return s.velocity
+}
+
+// Set moves the spring to value and velocity.
+func (s *Spring) Set(value, velocity float64) {
+	s.value = value
+	s.velocity = velocity
+}
+
+func (s *Spring) Done() bool {
+	return math.Abs(s.target-s.value) <= s.Precision && math.Abs(s.velocity) <= s.Precision
+}
+
+// Update integrates in steps of at most a millisecond, so the result does
+// not depend on the frame rate more than necessary.
+func (s *Spring) Update(dt time.Duration) (float64, bool) {
+	if dt <= 0 {
+		return s.value, s.Done()
+	}
+	mass := s.Mass
+	if mass <= 0 {
+		mass = 1
+	}
+	n := int((dt + springStep - 1) / springStep)
+	h := dt.Seconds() / float64(n)
+	for range n {
+		force := -s.Stiffness*(s.value-s.target) - s.Damping*s.velocity
+		s.velocity += force / mass * h
+		s.value += s.velocity * h
+	}
+	if s.Done() {
+		s.value, s.velocity = s.target, 0
+		return s.value, true
+	}
+	return s.value, false
+}
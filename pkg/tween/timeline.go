@@ -0,0 +1,254 @@
+package tween
+
+import (
+	"time"
+)
+
+func NewTimeline() *Timeline {
+	return &Timeline{
+		Scale: 1,
+	}
+}
+
+// Timeline plays tweens placed at offsets. Values are evaluated from the
+// position of the timeline, so large steps, seeking and reversing give the
+// same result as small steps.
+type Timeline struct {
+	// Scale multiplies dt, 0 pauses the timeline. Negative values are
+	// treated as 0, use Seek to move backwards.
+	Scale      float64
+	OnStart    func()
+	OnUpdate   func(progress float64)
+	OnComplete func()
+	steps      []*Step
+	cursor     time.Duration
+	span       time.Duration
+	pos        time.Duration
+	repeat     int
+	yoyo       bool
+	iteration  int
+	started    bool
+	completed  bool
+}
+
+// Step is a tween placed on a timeline. OnUpdate receives the tween value
+// while the step is active and once when it is passed.
+type Step struct {
+	OnStart    func()
+	OnUpdate   func(v float64)
+	OnComplete func()
+	start      time.Duration
+	tween      *Tween
+	entered    bool
+	passed     bool
+}
+
+// Add places t after the end of the previous step, offset moves it, a
+// negative offset overlaps the previous step.
+func (tl *Timeline) Add(t *Tween, offset time.Duration) *Step {
+	return tl.At(max(tl.cursor+offset, 0), t)
+}
+
+// At places t at an absolute position.
+func (tl *Timeline) At(at time.Duration, t *Tween) *Step {
+	s := &Step{
+		start: at,
+		tween: t,
+	}
+	tl.steps = append(tl.steps, s)
+	tl.cursor = s.end()
+	tl.span = max(tl.span, tl.cursor)
+	return s
+}
+
+// Bind writes the value of the step to target.
+func (s *Step) Bind(target *float64) *Step {
+	s.OnUpdate = func(v float64) {
+		*target = v
+	}
+	return s
+}
+
+func (s *Step) Start() time.Duration {
+	return s.start
+}
+
+func (s *Step) end() time.Duration {
+	total := s.tween.Total()
+	if total < 0 {
+		total = s.tween.delay + s.tween.duration
+	}
+	return s.start + total
+}
+
+// SetRepeat plays the timeline n more times, n < 0 repeats forever.
+func (tl *Timeline) SetRepeat(n int) {
+	tl.repeat = n
+}
+
+// SetYoyo plays every second repetition backwards.
+func (tl *Timeline) SetYoyo(yoyo bool) {
+	tl.yoyo = yoyo
+}
+
+// Duration returns the length of a single run.
+func (tl *Timeline) Duration() time.Duration {
+	return tl.span
+}
+
+// Total returns the length including repetitions, -1 if it repeats forever.
+func (tl *Timeline) Total() time.Duration {
+	if tl.repeat < 0 {
+		return -1
+	}
+	return tl.span * time.Duration(tl.repeat+1)
+}
+
+func (tl *Timeline) Elapsed() time.Duration {
+	return tl.pos
+}
+
+// Progress returns the position from 0 to 1, for endless timelines within
+// the current run.
+func (tl *Timeline) Progress() float64 {
+	if tl.span <= 0 {
+		return 1
+	}
+	total := tl.Total()
+	if total < 0 {
+		_, local := tl.split(tl.pos)
+		return float64(local) / float64(tl.span)
+	}
+	return float64(tl.pos) / float64(total)
+}
+
+func (tl *Timeline) Done() bool {
+	total := tl.Total()
+	return total >= 0 && tl.pos >= total
+}
+
+// Seek jumps to pos and applies the values there without calling the start
+// and complete callbacks.
+func (tl *Timeline) Seek(pos time.Duration) {
+	tl.pos = tl.clamp(pos)
+	tl.iteration, _ = tl.split(tl.pos)
+	tl.apply(false)
+	tl.completed = tl.Done()
+}
+
+// Reset seeks to the start and allows the callbacks to fire again.
+func (tl *Timeline) Reset() {
+	tl.Seek(0)
+	tl.started = false
+	tl.completed = false
+}
+
+// Update advances the timeline by dt times Scale and returns the progress.
+func (tl *Timeline) Update(dt time.Duration) (float64, bool) {
+	if !tl.started {
+		tl.started = true
+		if tl.OnStart != nil {
+			tl.OnStart()
+		}
+	}
+	to := tl.clamp(tl.pos + time.Duration(float64(dt)*max(tl.Scale, 0)))
+	for tl.span > 0 {
+		next := time.Duration(tl.iteration+1) * tl.span
+		if to <= next {
+			break
+		}
+		tl.pos = next
+		tl.apply(true)
+		tl.iteration++
+		for _, s := range tl.steps {
+			s.entered, s.passed = false, false
+		}
+	}
+	tl.pos = to
+	tl.apply(true)
+	progress := tl.Progress()
+	if tl.OnUpdate != nil {
+		tl.OnUpdate(progress)
+	}
+	done := tl.Done()
+	if done && !tl.completed {
+		tl.completed = true
+		if tl.OnComplete != nil {
+			tl.OnComplete()
+		}
+	}
+	return progress, done
+}
+
+func (tl *Timeline) clamp(pos time.Duration) time.Duration {
+	if total := tl.Total(); total >= 0 {
+		pos = min(pos, total)
+	}
+	return max(pos, 0)
+}
+
+// split returns the run and the position inside it, the end of a run
+// belongs to that run.
+func (tl *Timeline) split(pos time.Duration) (int, time.Duration) {
+	if tl.span <= 0 {
+		return 0, 0
+	}
+	iter := int(pos / tl.span)
+	local := pos - time.Duration(iter)*tl.span
+	if local == 0 && iter > 0 {
+		iter, local = iter-1, tl.span
+	}
+	return iter, local
+}
+
+func (tl *Timeline) apply(fire bool) {
+	iter, local := tl.split(tl.pos)
+	reverse := tl.yoyo && iter%2 == 1
+	if reverse {
+		local = tl.span - local
+	}
+	// steps seeked back to before their start are reset first, so steps
+	// sharing a target with them win
+	for _, s := range tl.steps {
+		if !fire && s.entered && !s.reached(local, reverse) {
+			s.entered, s.passed = false, false
+			s.update(s.tween.At(min(local, s.end()) - s.start))
+		}
+	}
+	for _, s := range tl.steps {
+		if !s.reached(local, reverse) {
+			continue
+		}
+		if !s.entered {
+			s.entered = true
+			if fire && s.OnStart != nil {
+				s.OnStart()
+			}
+		}
+		far := local >= s.end()
+		if reverse {
+			far = local <= s.start
+		}
+		if s.passed && far {
+			continue
+		}
+		s.update(s.tween.At(min(local, s.end()) - s.start))
+		s.passed = far
+		if far && fire && s.OnComplete != nil {
+			s.OnComplete()
+		}
+	}
+}
+
+func (s *Step) reached(local time.Duration, reverse bool) bool {
+	if reverse {
+		return local <= s.end()
+	}
+	return local >= s.start
+}
+
+func (s *Step) update(v float64) {
+	if s.OnUpdate != nil {
+		s.OnUpdate(v)
+	}
+}
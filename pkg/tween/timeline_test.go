@@ -0,0 +1,145 @@
+package tween
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTweenRepeatYoyo(t *testing.T) {
+	tw := New(0, 10, 100*ms, Linear)
+	tw.SetDelay(50 * ms)
+	tw.SetRepeat(1)
+	tw.SetYoyo(true)
+	if tw.Total() != 250*ms {
+		t.Errorf("expected a total of 250ms but got %s", tw.Total())
+	}
+	for at, expected := range map[time.Duration]float64{
+		20 * ms:  0,
+		100 * ms: 5,
+		150 * ms: 10,
+		175 * ms: 7.5,
+		300 * ms: 0,
+	} {
+		if v := tw.At(at); !near(v, expected) {
+			t.Errorf("at %s: expected %f but got %f", at, expected, v)
+		}
+	}
+	if _, done := tw.Update(260 * ms); !done || tw.Overshoot() != 10*ms {
+		t.Errorf("expected 10ms overshoot but got %s", tw.Overshoot())
+	}
+}
+
+func TestTweenZeroDuration(t *testing.T) {
+	tw := New(0, 10, 0, Linear)
+	tw.SetDelay(50 * ms)
+	if v := tw.At(20 * ms); v != 0 {
+		t.Errorf("expected the begin value during the delay but got %f", v)
+	}
+	if v := tw.At(50 * ms); v != 10 {
+		t.Errorf("expected the end value after the delay but got %f", v)
+	}
+	if v, done := tw.Update(60 * ms); !done || v != 10 {
+		t.Errorf("expected the tween to finish at 10 but got %f done=%t", v, done)
+	}
+}
+
+func TestSequenceCarriesOver(t *testing.T) {
+	s := NewSeq(New(0, 10, 100*ms, Linear), New(10, 0, 100*ms, Linear))
+	s.Update(100 * ms)
+	if v, _ := s.Update(50 * ms); !near(v, 5) {
+		t.Errorf("expected overshoot to reach the second tween but got %f", v)
+	}
+	s.SetLoop(true)
+	if v, done := s.Update(100 * ms); !done || !near(v, 5) {
+		t.Errorf("expected the loop to restart with the overshoot but got %f", v)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	var x, y float64
+	events := []string{}
+	log := func(e string) func() { return func() { events = append(events, e) } }
+
+	tl := NewTimeline()
+	first := tl.Add(New(0, 100, 100*ms, Linear), 0).Bind(&x)
+	first.OnStart, first.OnComplete = log("x start"), log("x done")
+	second := tl.Add(New(0, 10, 100*ms, Linear), 50*ms).Bind(&y)
+	second.OnStart, second.OnComplete = log("y start"), log("y done")
+	tl.OnComplete = log("done")
+
+	if tl.Duration() != 250*ms || second.Start() != 150*ms {
+		t.Fatalf("unexpected layout %s %s", tl.Duration(), second.Start())
+	}
+	tl.Update(175 * ms)
+	if x != 100 || !near(y, 2.5) {
+		t.Errorf("expected x=100 y=2.5 but got %f %f", x, y)
+	}
+	tl.Update(time.Second)
+	expected := []string{"x start", "x done", "y start", "y done", "done"}
+	if !slices.Equal(events, expected) || y != 10 {
+		t.Errorf("expected events %v but got %v", expected, events)
+	}
+
+	events = events[:0]
+	tl.Seek(50 * ms)
+	if x != 50 || y != 0 || len(events) != 0 {
+		t.Errorf("expected a silent seek to x=50 y=0 but got %f %f %v", x, y, events)
+	}
+}
+
+func TestTimelineStepSizes(t *testing.T) {
+	build := func(x, y *float64) *Timeline {
+		tl := NewTimeline()
+		tl.Add(New(0, 1, 70*ms, InOutQuad), 0).Bind(x)
+		tl.Add(New(1, 0, 40*ms, OutQuad), -20*ms).Bind(y)
+		tl.SetRepeat(2)
+		tl.SetYoyo(true)
+		return tl
+	}
+	var x1, y1, x2, y2 float64
+	small, big := build(&x1, &y1), build(&x2, &y2)
+	for range 19 {
+		small.Update(10 * ms)
+	}
+	big.Update(190 * ms)
+	if !near(x1, x2) || !near(y1, y2) {
+		t.Errorf("step size changed the result: %f %f vs %f %f", x1, y1, x2, y2)
+	}
+	if !near(big.Progress(), 190.0/270) {
+		t.Errorf("unexpected progress %f", big.Progress())
+	}
+}
+
+func TestTimelineYoyoScale(t *testing.T) {
+	x := 0.0
+	tl := NewTimeline()
+	tl.Add(New(0, 100, 100*ms, Linear), 0).Bind(&x)
+	tl.SetRepeat(1)
+	tl.SetYoyo(true)
+	tl.Scale = 2
+
+	tl.Update(75 * ms)
+	if !near(x, 50) {
+		t.Errorf("expected the second run to play backwards to 50 but got %f", x)
+	}
+	if _, done := tl.Update(50 * ms); !done || x != 0 {
+		t.Errorf("expected the timeline to end at 0 but got %f", x)
+	}
+}
+
+func TestTimelineNegativeScale(t *testing.T) {
+	x := 0.0
+	completed := 0
+	tl := NewTimeline()
+	tl.Add(New(0, 100, 100*ms, Linear), 0).Bind(&x).OnComplete = func() { completed++ }
+	tl.Update(50 * ms)
+
+	tl.Scale = -1
+	if progress, done := tl.Update(50 * ms); done || !near(x, 50) || !near(progress, 0.5) {
+		t.Errorf("expected a negative scale to hold the timeline at 50 but got x=%f progress=%f", x, progress)
+	}
+	if completed != 0 {
+		t.Errorf("expected no completion but got %d", completed)
+	}
+}
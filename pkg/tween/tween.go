@@ -26,28 +26,101 @@ type Tween struct {
 	elapsed  time.Duration
 	easing   TweenFunc
 	loop     bool
+	delay    time.Duration
+	repeat   int
+	yoyo     bool
 }
 
 func (t *Tween) SetLoop(loop bool) {
 	t.loop = loop
 }
 
+// SetDelay holds the begin value for d before the tween starts.
+func (t *Tween) SetDelay(d time.Duration) {
+	t.delay = d
+}
+
+// SetRepeat plays the tween n more times after the first run, n < 0 repeats
+// forever.
+func (t *Tween) SetRepeat(n int) {
+	t.repeat = n
+}
+
+// SetYoyo plays every second repetition backwards.
+func (t *Tween) SetYoyo(yoyo bool) {
+	t.yoyo = yoyo
+}
+
 func (t *Tween) Reset() {
 	t.elapsed = 0
 }
 
+func (t *Tween) Duration() time.Duration {
+	return t.duration
+}
+
+// Total returns the duration including delay and repetitions, -1 if the
+// tween repeats forever.
+func (t *Tween) Total() time.Duration {
+	if t.repeat < 0 {
+		return -1
+	}
+	return t.delay + t.duration*time.Duration(t.repeat+1)
+}
+
+// Elapsed returns the time played so far.
+func (t *Tween) Elapsed() time.Duration {
+	return t.elapsed
+}
+
+// Overshoot returns how much the last Update went past the end of the tween.
+func (t *Tween) Overshoot() time.Duration {
+	total := t.Total()
+	if total < 0 {
+		return 0
+	}
+	return max(t.elapsed-total, 0)
+}
+
+// Seek moves the tween to elapsed and returns the value there.
+func (t *Tween) Seek(elapsed time.Duration) float64 {
+	t.elapsed = elapsed
+	return t.At(elapsed)
+}
+
+// At returns the value at elapsed without moving the tween. A tween without
+// duration jumps to its end value once its delay has passed.
+func (t *Tween) At(elapsed time.Duration) float64 {
+	local := elapsed - t.delay
+	if local < 0 {
+		return t.begin
+	}
+	if t.duration <= 0 {
+		return t.end
+	}
+	iter := int(local / t.duration)
+	local -= time.Duration(iter) * t.duration
+	if t.repeat >= 0 && iter > t.repeat {
+		iter, local = t.repeat, t.duration
+	} else if local == 0 && iter > 0 {
+		iter, local = iter-1, t.duration
+	}
+	if t.yoyo && iter%2 == 1 {
+		local = t.duration - local
+	}
+	return t.easing(local.Seconds(), t.begin, t.change, t.duration.Seconds())
+}
+
 func (t *Tween) Update(dt time.Duration) (float64, bool) {
 	t.elapsed += dt
-	done := t.elapsed > t.duration
-	var curTime float64
-	if done {
-		curTime = t.duration.Seconds()
-		if t.loop {
-			t.elapsed = t.elapsed - t.duration
-		}
-	} else {
-		curTime = t.elapsed.Seconds()
-	}
-	val := t.easing(curTime, t.begin, t.change, t.duration.Seconds())
-	return val, done
+	total := t.Total()
+	done := total >= 0 && t.elapsed > total
+	if !done {
+		return t.At(t.elapsed), false
+	}
+	val := t.At(total)
+	if t.loop {
+		t.elapsed -= total
+	}
+	return val, true
 }
@@ -0,0 +1,114 @@
+package tween
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Interpolator is implemented by types that can be tweened with NewOf.
+type Interpolator[T any] interface {
+	Lerp(to T, t float64) T
+}
+
+// Progress returns a tween from 0 to 1, the progress of the typed tweens.
+func Progress(duration time.Duration, easing TweenFunc) *Tween {
+	return New(0, 1, duration, easing)
+}
+
+// NewValue tweens between from and to with lerp. progress drives the tween
+// and is expected to run from 0 to 1, any Updater works, e.g. a Sequence
+// from 0 to 1 and back.
+func NewValue[T any](from, to T, progress Updater, lerp func(a, b T, t float64) T) *Value[T] {
+	return &Value[T]{
+		From:     from,
+		To:       to,
+		progress: progress,
+		lerp:     lerp,
+	}
+}
+
+// NewVector tweens a vector along a straight line.
+func NewVector(from, to xmath.Vector2, progress Updater) *Value[xmath.Vector2] {
+	return NewValue(from, to, progress, xmath.Lerp)
+}
+
+// NewColor tweens each channel of a color.
+func NewColor(from, to color.RGBA, progress Updater) *Value[color.RGBA] {
+	return NewValue(from, to, progress, LerpColor)
+}
+
+// NewColorScale tweens each channel of a color scale, e.g. to fade or tint
+// the ColorScale of draw options.
+func NewColorScale(from, to ebiten.ColorScale, progress Updater) *Value[ebiten.ColorScale] {
+	return NewValue(from, to, progress, LerpColorScale)
+}
+
+// NewAngle tweens an angle in radians along the shorter way around the
+// circle.
+func NewAngle(from, to float64, progress Updater) *Value[float64] {
+	return NewValue(from, to, progress, LerpAngle)
+}
+
+// NewOf tweens any type implementing Interpolator.
+func NewOf[T Interpolator[T]](from, to T, progress Updater) *Value[T] {
+	return NewValue(from, to, progress, func(a, b T, t float64) T {
+		return a.Lerp(b, t)
+	})
+}
+
+type Value[T any] struct {
+	From     T
+	To       T
+	progress Updater
+	lerp     func(a, b T, t float64) T
+}
+
+// Progress returns the Updater driving the tween.
+func (v *Value[T]) Progress() Updater {
+	return v.progress
+}
+
+func (v *Value[T]) Update(dt time.Duration) (T, bool) {
+	t, done := v.progress.Update(dt)
+	return v.lerp(v.From, v.To, t), done
+}
+
+// LerpColor interpolates each channel and clamps the result, so easings
+// overshooting 0..1 stay valid colors.
+func LerpColor(a, b color.RGBA, t float64) color.RGBA {
+	ch := func(a, b uint8) uint8 {
+		v := float64(a) + (float64(b)-float64(a))*t
+		return uint8(xmath.Clamp(math.Round(v), 0, 255))
+	}
+	return color.RGBA{
+		R: ch(a.R, b.R),
+		G: ch(a.G, b.G),
+		B: ch(a.B, b.B),
+		A: ch(a.A, b.A),
+	}
+}
+
+// LerpColorScale interpolates each channel. Scales are not clamped, values
+// above 1 brighten and easings may overshoot.
+func LerpColorScale(a, b ebiten.ColorScale, t float64) ebiten.ColorScale {
+	ch := func(a, b float32) float32 {
+		return a + (b-a)*float32(t)
+	}
+	var c ebiten.ColorScale
+	c.SetR(ch(a.R(), b.R()))
+	c.SetG(ch(a.G(), b.G()))
+	c.SetB(ch(a.B(), b.B()))
+	c.SetA(ch(a.A(), b.A()))
+	return c
+}
+
+// LerpAngle interpolates between two angles in radians taking the shorter
+// way, the result is not normalized.
+func LerpAngle(a, b float64, t float64) float64 {
+	return a + math.Remainder(b-a, 2*math.Pi)*t
+}
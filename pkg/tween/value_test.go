@@ -0,0 +1,79 @@
+package tween
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/weakpixel/ebitenkiso/pkg/xmath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type scale struct {
+	x, y float64
+}
+
+func (s scale) Lerp(to scale, t float64) scale {
+	return scale{s.x + (to.x-s.x)*t, s.y + (to.y-s.y)*t}
+}
+
+func TestVector(t *testing.T) {
+	v := NewVector(xmath.Vector2{}, xmath.Vector2{X: 100, Y: 50}, Progress(time.Second, Linear))
+	pos, done := v.Update(250 * time.Millisecond)
+	if pos != (xmath.Vector2{X: 25, Y: 12.5}) || done {
+		t.Errorf("expected {25 12.5} but got %v", pos)
+	}
+}
+
+func TestVectorSequence(t *testing.T) {
+	seq := NewSeq(Progress(time.Second, Linear), New(1, 0, time.Second, Linear))
+	v := NewVector(xmath.Vector2{}, xmath.Vector2{X: 10, Y: 10}, seq)
+	v.Update(time.Second)
+	pos, _ := v.Update(500 * time.Millisecond)
+	if math.Abs(pos.X-5) > 1e-9 || pos.X != pos.Y {
+		t.Errorf("expected the way back at {5 5} but got %v", pos)
+	}
+}
+
+func TestColor(t *testing.T) {
+	v := NewColor(color.RGBA{0, 0, 0, 255}, color.RGBA{255, 100, 0, 255}, Progress(time.Second, Linear))
+	c, _ := v.Update(500 * time.Millisecond)
+	if c != (color.RGBA{128, 50, 0, 255}) {
+		t.Errorf("unexpected color %v", c)
+	}
+	if c := LerpColor(color.RGBA{}, color.RGBA{R: 200}, 1.5); c.R != 255 {
+		t.Errorf("expected overshoot to clamp but got %v", c)
+	}
+}
+
+func TestColorScale(t *testing.T) {
+	var to ebiten.ColorScale
+	to.Scale(0, 0.5, 2, 0)
+	v := NewColorScale(ebiten.ColorScale{}, to, Progress(time.Second, Linear))
+	c, _ := v.Update(500 * time.Millisecond)
+	if c.R() != 0.5 || c.G() != 0.75 || c.B() != 1.5 || c.A() != 0.5 {
+		t.Errorf("unexpected color scale %v", c)
+	}
+	if c, _ := v.Update(500 * time.Millisecond); c != to {
+		t.Errorf("expected %v at the end but got %v", to, c)
+	}
+}
+
+func TestAngleShortestPath(t *testing.T) {
+	from, to := xmath.DegToRad(350), xmath.DegToRad(10)
+	v := NewAngle(from, to, Progress(time.Second, Linear))
+	a, _ := v.Update(500 * time.Millisecond)
+	if math.Abs(a-2*math.Pi) > 1e-9 {
+		t.Errorf("expected to pass 360 degrees but got %f", xmath.RadToDeg(a))
+	}
+}
+
+func TestOf(t *testing.T) {
+	v := NewOf(scale{1, 1}, scale{2, 3}, Progress(time.Second, Linear))
+	s, _ := v.Update(500 * time.Millisecond)
+	if s != (scale{1.5, 2}) {
+		t.Errorf("unexpected value %v", s)
+	}
+}
@@ -0,0 +1,32 @@
+package xmath
+
+// Camera maps between world space and screen space. Position is the world
+// point shown at the center of a viewport of Width x Height, Zoom scales
+// the world (0 counts as 1) and Rotation turns it in radians.
+type Camera struct {
+	Position      Vector2
+	Zoom          float64
+	Rotation      float64
+	Width, Height float64
+}
+
+func (c Camera) zoom() float64 {
+	if c.Zoom == 0 {
+		return 1
+	}
+	return c.Zoom
+}
+
+// WorldToScreen converts a world position to screen pixels.
+// Example: lightX, lightY := cam.WorldToScreen(torch.Pos)
+func (c Camera) WorldToScreen(p Vector2) Vector2 {
+	rel := Rotate(p.Sub(c.Position), -c.Rotation).Mul(c.zoom())
+	return rel.Add(Vector2{c.Width / 2, c.Height / 2})
+}
+
+// ScreenToWorld converts screen pixels to a world position.
+// Example: target := cam.ScreenToWorld(Vector2{float64(mx), float64(my)})
+func (c Camera) ScreenToWorld(p Vector2) Vector2 {
+	rel := p.Sub(Vector2{c.Width / 2, c.Height / 2}).Div(c.zoom())
+	return Rotate(rel, c.Rotation).Add(c.Position)
+}
@@ -0,0 +1,18 @@
+package xmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCamera(t *testing.T) {
+	cam := Camera{Position: Vector2{100, 50}, Zoom: 2, Width: 320, Height: 180}
+	if got := cam.WorldToScreen(Vector2{110, 50}); got != (Vector2{180, 90}) {
+		t.Errorf("expected {180 90} but got %v", got)
+	}
+	cam.Rotation = math.Pi / 3
+	p := Vector2{42, -7}
+	if back := cam.ScreenToWorld(cam.WorldToScreen(p)); back.Distance(p) > 1e-9 {
+		t.Errorf("expected a round trip to %v but got %v", p, back)
+	}
+}
@@ -79,6 +79,12 @@ type Polygon struct {
 	Points []Vector2 // Using Vector2 from other file
 }
 
+// Transform returns the points rotated by rot and moved to pos.
+// Example: world := hull.Transform(entity.Pos, entity.Rotation)
+func (p Polygon) Transform(pos Vector2, rot float64) []Vector2 {
+	return transformPolygon(p, pos, rot)
+}
+
 // ========================
 // COLLISION DETECTION
 // ========================